@@ -18,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/config"
 	"github.com/open-telemetry/opentelemetry-collector/defaults"
 	"github.com/open-telemetry/opentelemetry-collector/exporter"
+	"github.com/open-telemetry/opentelemetry-collector/extension"
 	"github.com/open-telemetry/opentelemetry-collector/oterr"
 	"github.com/open-telemetry/opentelemetry-collector/processor"
 	"github.com/open-telemetry/opentelemetry-collector/receiver"
@@ -27,8 +28,19 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kinesisexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sapmexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxcorrelationexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/stackdriverexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/asapauthextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/awsproxy"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/collectdreceiver"
@@ -44,6 +56,26 @@ func components() (config.Factories, error) {
 		return config.Factories{}, err
 	}
 
+	extensions := []extension.Factory{
+		&asapauthextension.Factory{},
+		&awsproxy.Factory{},
+		&basicauthextension.Factory{},
+		&bearertokenauthextension.Factory{},
+		&headerssetterextension.Factory{},
+		&httpforwarder.Factory{},
+		&oauth2clientauthextension.Factory{},
+		&oidcauthextension.Factory{},
+		&sigv4authextension.Factory{},
+		&filestorage.Factory{},
+	}
+	for _, ext := range factories.Extensions {
+		extensions = append(extensions, ext)
+	}
+	factories.Extensions, err = extension.Build(extensions...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
 	receivers := []receiver.Factory{
 		&collectdreceiver.Factory{},
 		&sapmreceiver.Factory{},
@@ -64,6 +96,7 @@ func components() (config.Factories, error) {
 		&azuremonitorexporter.Factory{},
 		&signalfxexporter.Factory{},
 		&sapmexporter.Factory{},
+		&signalfxcorrelationexporter.Factory{},
 		&kinesisexporter.Factory{},
 		&awsxrayexporter.Factory{},
 		&carbonexporter.Factory{},
@@ -25,14 +25,15 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter/translator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
 )
 
 // NewTraceExporter creates an exporter.TraceExporter that converts to an X-Ray PutTraceSegments
 // request and then posts the request to the configured region's X-Ray endpoint.
-func NewTraceExporter(config configmodels.Exporter, logger *zap.Logger, cn connAttr) (exporter.TraceExporter, error) {
+func NewTraceExporter(config configmodels.Exporter, logger *zap.Logger, cn awsutil.ConnAttr) (exporter.TraceExporter, error) {
 	typeLog := zap.String("type", config.Type())
 	nameLog := zap.String("name", config.Name())
-	awsConfig, session, err := GetAWSConfigSession(logger, cn, config.(*Config))
+	awsConfig, session, err := awsutil.GetAWSConfigSession(logger, cn, &config.(*Config).AWSSessionSettings)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +42,7 @@ func NewTraceExporter(config configmodels.Exporter, logger *zap.Logger, cn connA
 		config,
 		func(ctx context.Context, td consumerdata.TraceData) (int, error) {
 			logger.Debug("TraceExporter", typeLog, nameLog, zap.Int("#spans", len(td.Spans)))
-			droppedSpans, input := assembleRequest(td, logger)
+			droppedSpans, input := assembleRequest(td, config.(*Config), logger)
 			logger.Debug("request: " + input.String())
 			output, err := xrayClient.PutTraceSegments(input)
 			if config.(*Config).LocalMode {
@@ -54,12 +55,12 @@ func NewTraceExporter(config configmodels.Exporter, logger *zap.Logger, cn connA
 			return droppedSpans, err
 		},
 		exporterhelper.WithTracing(true),
-		exporterhelper.WithMetrics(false),
+		exporterhelper.WithMetrics(true),
 		exporterhelper.WithShutdown(logger.Sync),
 	)
 }
 
-func assembleRequest(td consumerdata.TraceData, logger *zap.Logger) (int, *xray.PutTraceSegmentsInput) {
+func assembleRequest(td consumerdata.TraceData, config *Config, logger *zap.Logger) (int, *xray.PutTraceSegmentsInput) {
 	documents := make([]*string, len(td.Spans))
 	droppedSpans := int(0)
 	for i, span := range td.Spans {
@@ -68,7 +69,8 @@ func assembleRequest(td consumerdata.TraceData, logger *zap.Logger) (int, *xray.
 			continue
 		}
 		spanName := span.Name.Value
-		jsonStr, err := translator.MakeSegmentDocumentString(spanName, span)
+		jsonStr, err := translator.MakeSegmentDocumentString(
+			spanName, span, config.IndexedAttributes, config.IndexAllAttributes)
 		if err != nil {
 			droppedSpans++
 			logger.Warn("Unable to convert span", zap.Error(err))
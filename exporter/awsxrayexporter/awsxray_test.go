@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/session"
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"github.com/golang/protobuf/ptypes/timestamp"
@@ -35,6 +36,18 @@ import (
 	"go.uber.org/zap"
 )
 
+type mockConn struct {
+	sn *session.Session
+}
+
+func (c *mockConn) GetEC2Region(s *session.Session) (string, error) {
+	return "us-east-1", nil
+}
+
+func (c *mockConn) NewAWSSession(logger *zap.Logger, roleArn string, region string) (*session.Session, error) {
+	return c.sn, nil
+}
+
 func TestTraceExport(t *testing.T) {
 	traceExporter := initializeTraceExporter()
 	ctx := context.Background()
@@ -54,7 +67,7 @@ func initializeTraceExporter() exporter.TraceExporter {
 	config.(*Config).Region = "us-east-1"
 	config.(*Config).LocalMode = true
 	mconn := new(mockConn)
-	mconn.sn, _ = getDefaultSession(logger)
+	mconn.sn, _ = session.NewSession()
 	traceExporter, err := NewTraceExporter(config, logger, mconn)
 	if err != nil {
 		panic(err)
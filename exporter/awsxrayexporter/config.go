@@ -14,29 +14,20 @@
 
 package awsxrayexporter
 
-import "github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+)
 
 // Config defines configuration for AWS X-Ray exporter.
 type Config struct {
 	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	// Maximum number of concurrent calls to AWS X-Ray to upload documents.
-	NumberOfWorkers int `mapstructure:"num_workers"`
-	// X-Ray service endpoint to which the collector sends segment documents.
-	Endpoint string `mapstructure:"endpoint"`
-	// Number of seconds before timing out a request.
-	RequestTimeoutSeconds int `mapstructure:"request_timeout_seconds"`
-	// Maximum number of retries before abandoning an attempt to post data.
-	MaxRetries int `mapstructure:"max_retries"`
-	// Enable or disable TLS certificate verification.
-	NoVerifySSL bool `mapstructure:"no_verify_ssl"`
-	// Upload segments to AWS X-Ray through a proxy.
-	ProxyAddress string `mapstructure:"proxy_address"`
-	// Send segments to AWS X-Ray service in a specific region.
-	Region string `mapstructure:"region"`
-	// Local mode to skip EC2 instance metadata check.
-	LocalMode bool `mapstructure:"local_mode"`
-	// Amazon Resource Name (ARN) of the AWS resource running the collector.
-	ResourceARN string `mapstructure:"resource_arn"`
-	// IAM role to upload segments to a different account.
-	RoleARN string `mapstructure:"role_arn"`
+	// AWSSessionSettings are the settings common to all AWS components.
+	awsutil.AWSSessionSettings `mapstructure:",squash"`
+	// IndexedAttributes is the list of span attribute names converted to X-Ray annotations,
+	// which are indexed and searchable. Other attributes are converted to metadata.
+	IndexedAttributes []string `mapstructure:"indexed_attributes"`
+	// IndexAllAttributes converts all span attributes to X-Ray annotations when set.
+	IndexAllAttributes bool `mapstructure:"index_all_attributes"`
 }
@@ -22,6 +22,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -45,16 +47,19 @@ func TestLoadConfig(t *testing.T) {
 	r1 := cfg.Exporters["awsxray/customname"].(*Config)
 	assert.Equal(t, r1,
 		&Config{
-			ExporterSettings:      configmodels.ExporterSettings{TypeVal: typeStr, NameVal: "awsxray/customname"},
-			NumberOfWorkers:       8,
-			Endpoint:              "",
-			RequestTimeoutSeconds: 30,
-			MaxRetries:            2,
-			NoVerifySSL:           false,
-			ProxyAddress:          "",
-			Region:                "eu-west-1",
-			LocalMode:             false,
-			ResourceARN:           "arn:aws:ec2:us-east1:123456789:instance/i-293hiuhe0u",
-			RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
+			ExporterSettings: configmodels.ExporterSettings{TypeVal: typeStr, NameVal: "awsxray/customname"},
+			AWSSessionSettings: awsutil.AWSSessionSettings{
+				NumberOfWorkers:       8,
+				Endpoint:              "",
+				RequestTimeoutSeconds: 30,
+				MaxRetries:            2,
+				NoVerifySSL:           false,
+				ProxyAddress:          "",
+				Region:                "eu-west-1",
+				LocalMode:             false,
+				ResourceARN:           "arn:aws:ec2:us-east1:123456789:instance/i-293hiuhe0u",
+				RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
+			},
+			IndexedAttributes: []string{"attr1", "attr2"},
 		})
 }
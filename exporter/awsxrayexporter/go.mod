@@ -9,9 +9,12 @@ require (
 	github.com/konsorten/go-windows-terminal-sequences v1.0.2 // indirect
 	github.com/mattn/go-isatty v0.0.10 // indirect
 	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil v0.0.0
 	github.com/stretchr/testify v1.4.0
 	go.uber.org/zap v1.10.0
 	golang.org/x/net v0.0.0-20190923162816-aa69164e4478
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e // indirect
 	golang.org/x/tools v0.0.0-20191030062658-86caa796c7ab // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil => ../../internal/aws/awsutil
@@ -54,6 +54,8 @@ const (
 	defaultSegmentName = "span"
 	// maxSegmentNameLength the maximum length of a Segment name
 	maxSegmentNameLength = 200
+	// defaultMetadataNamespace is the metadata namespace of the span attributes that are not indexed
+	defaultMetadataNamespace = "default"
 )
 
 const (
@@ -103,8 +105,8 @@ var (
 )
 
 // MakeSegmentDocumentString converts an OpenCensus Span to an X-Ray Segment and then serialzies to JSON
-func MakeSegmentDocumentString(name string, span *tracepb.Span) (string, error) {
-	segment := MakeSegment(name, span)
+func MakeSegmentDocumentString(name string, span *tracepb.Span, indexedAttrs []string, indexAllAttrs bool) (string, error) {
+	segment := MakeSegment(name, span, indexedAttrs, indexAllAttrs)
 	w := writers.borrow()
 	if err := w.Encode(segment); err != nil {
 		return "", err
@@ -114,8 +116,10 @@ func MakeSegmentDocumentString(name string, span *tracepb.Span) (string, error)
 	return jsonStr, nil
 }
 
-// MakeSegment converts an OpenCensus Span to an X-Ray Segment
-func MakeSegment(name string, span *tracepb.Span) Segment {
+// MakeSegment converts an OpenCensus Span to an X-Ray Segment. The span attributes listed in
+// indexedAttrs, or all of them if indexAllAttrs is set, are converted to annotations, the
+// remaining ones are added to the segment metadata.
+func MakeSegment(name string, span *tracepb.Span, indexedAttrs []string, indexAllAttrs bool) Segment {
 	var (
 		traceID                                = convertToAmazonTraceID(span.TraceId)
 		startTime                              = timestampToFloatSeconds(span.StartTime, span.StartTime)
@@ -127,7 +131,7 @@ func MakeSegment(name string, span *tracepb.Span) Segment {
 		awsfiltered, aws                       = makeAws(causefiltered, span.Resource)
 		service                                = makeService(span.Resource)
 		sqlfiltered, sql                       = makeSQL(awsfiltered)
-		user, annotations                      = makeAnnotations(sqlfiltered)
+		user, annotations, metadata            = makeXRayAttributes(sqlfiltered, indexedAttrs, indexAllAttrs)
 		namespace                              string
 	)
 
@@ -157,7 +161,7 @@ func MakeSegment(name string, span *tracepb.Span) Segment {
 		Service:     service,
 		SQL:         sql,
 		Annotations: annotations,
-		Metadata:    nil,
+		Metadata:    metadata,
 	}
 }
 
@@ -267,17 +271,12 @@ func timestampToFloatSeconds(ts *timestamp.Timestamp, startTs *timestamp.Timesta
 	return float64(t.UnixNano()) / 1e9
 }
 
-func sanitizeAndTransferAnnotations(dest map[string]interface{}, src map[string]string) {
-	for key, value := range src {
-		key = fixAnnotationKey(key)
-		dest[key] = value
-	}
-}
-
-func makeAnnotations(attributes map[string]string) (string, map[string]interface{}) {
+func makeXRayAttributes(attributes map[string]string, indexedAttrs []string, indexAllAttrs bool) (
+	string, map[string]interface{}, map[string]map[string]interface{}) {
 	var (
-		result = map[string]interface{}{}
-		user   string
+		annotations = map[string]interface{}{}
+		metadata    = map[string]interface{}{}
+		user        string
 	)
 	delete(attributes, semconventions.AttributeComponent)
 	userid, ok := attributes[semconventions.AttributeEnduserID]
@@ -285,12 +284,29 @@ func makeAnnotations(attributes map[string]string) (string, map[string]interface
 		user = userid
 		delete(attributes, semconventions.AttributeEnduserID)
 	}
-	sanitizeAndTransferAnnotations(result, attributes)
 
-	if len(result) == 0 {
-		return user, nil
+	indexedKeys := make(map[string]struct{}, len(indexedAttrs))
+	for _, key := range indexedAttrs {
+		indexedKeys[key] = struct{}{}
+	}
+
+	for key, value := range attributes {
+		if _, indexed := indexedKeys[key]; indexed || indexAllAttrs {
+			annotations[fixAnnotationKey(key)] = value
+		} else {
+			metadata[key] = value
+		}
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	if len(metadata) == 0 {
+		return user, annotations, nil
+	}
+	return user, annotations, map[string]map[string]interface{}{
+		defaultMetadataNamespace: metadata,
 	}
-	return user, result
 }
 
 // fixSegmentName removes any invalid characters from the span name.  AWS X-Ray defines
@@ -47,7 +47,7 @@ func TestClientSpanWithGrpcComponent(t *testing.T) {
 	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTime)
 	span.TimeEvents = &timeEvents
 
-	jsonStr, err := MakeSegmentDocumentString(spanName, span)
+	jsonStr, err := MakeSegmentDocumentString(spanName, span, nil, false)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
@@ -70,7 +70,7 @@ func TestClientSpanWithAwsSdkClient(t *testing.T) {
 	labels := constructDefaultResourceLabels()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes, labels)
 
-	jsonStr, err := MakeSegmentDocumentString(spanName, span)
+	jsonStr, err := MakeSegmentDocumentString(spanName, span, nil, false)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
@@ -99,7 +99,7 @@ func TestServerSpanWithInternalServerError(t *testing.T) {
 	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTime)
 	span.TimeEvents = &timeEvents
 
-	segment := MakeSegment(spanName, span)
+	segment := MakeSegment(spanName, span, nil, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -133,13 +133,14 @@ func TestClientSpanWithDbComponent(t *testing.T) {
 	labels := constructDefaultResourceLabels()
 	span := constructClientSpan(nil, spanName, 0, "OK", attributes, labels)
 
-	segment := MakeSegment(spanName, span)
+	segment := MakeSegment(spanName, span, nil, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.SQL)
 	assert.NotNil(t, segment.Service)
 	assert.NotNil(t, segment.AWS)
-	assert.NotNil(t, segment.Annotations)
+	assert.Nil(t, segment.Annotations)
+	assert.Equal(t, enterpriseAppID, segment.Metadata["default"]["enterprise.app.id"])
 	assert.Nil(t, segment.Cause)
 	assert.Nil(t, segment.HTTP)
 	assert.Equal(t, spanName, segment.Name)
@@ -155,6 +156,30 @@ func TestClientSpanWithDbComponent(t *testing.T) {
 	assert.True(t, strings.Contains(jsonStr, enterpriseAppID))
 }
 
+func TestSpanWithIndexedAttributes(t *testing.T) {
+	spanName := "/api/locations"
+	attributes := make(map[string]interface{})
+	attributes[semconventions.AttributeComponent] = semconventions.ComponentTypeHTTP
+	attributes["tenant.id"] = "tenant1"
+	attributes["order.id"] = "order1"
+	labels := constructDefaultResourceLabels()
+
+	span := constructServerSpan(nil, spanName, 0, "OK", attributes, labels)
+	segment := MakeSegment(spanName, span, []string{"tenant.id", "unknown"}, false)
+	assert.Equal(t, map[string]interface{}{"tenant_id": "tenant1"}, segment.Annotations)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"default": {"order.id": "order1"},
+	}, segment.Metadata)
+
+	span = constructServerSpan(nil, spanName, 0, "OK", attributes, labels)
+	segment = MakeSegment(spanName, span, nil, true)
+	assert.Equal(t, map[string]interface{}{
+		"tenant_id": "tenant1",
+		"order_id":  "order1",
+	}, segment.Annotations)
+	assert.Nil(t, segment.Metadata)
+}
+
 func TestSpanWithInvalidTraceId(t *testing.T) {
 	spanName := "platformapi.widgets.searchWidgets"
 	attributes := make(map[string]interface{})
@@ -170,7 +195,7 @@ func TestSpanWithInvalidTraceId(t *testing.T) {
 	span.TimeEvents = &timeEvents
 	span.TraceId[0] = 0x11
 
-	jsonStr, err := MakeSegmentDocumentString(spanName, span)
+	jsonStr, err := MakeSegmentDocumentString(spanName, span, nil, false)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
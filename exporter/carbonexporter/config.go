@@ -18,6 +18,8 @@ import (
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry"
 )
 
 // Defaults for not specified configuration settings.
@@ -38,6 +40,10 @@ type Config struct {
 	// data to the Carbon/Graphite backend.
 	// The default value is defined by the DefaultSendTimeout constant.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// ResourceToTelemetrySettings defines whether the resource labels should be
+	// added as tags of the metrics, otherwise they are not exported.
+	ResourceToTelemetrySettings resourcetotelemetry.Settings `mapstructure:"resource_to_telemetry_conversion"`
 }
 
 // convenience function so the default can be created without instantiating the
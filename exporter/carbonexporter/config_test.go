@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -53,6 +55,9 @@ func TestLoadConfig(t *testing.T) {
 		},
 		Endpoint: "localhost:8080",
 		Timeout:  10 * time.Second,
+		ResourceToTelemetrySettings: resourcetotelemetry.Settings{
+			Enabled: true,
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -24,6 +24,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
 	"github.com/open-telemetry/opentelemetry-collector/exporter"
 	"github.com/open-telemetry/opentelemetry-collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry"
 )
 
 // New returns a new Carbon exporter.
@@ -50,12 +52,17 @@ func New(config Config) (exporter.MetricsExporter, error) {
 		connPool: newTCPConnPool(effectiveConfig.Endpoint, effectiveConfig.Timeout),
 	}
 
-	return exporterhelper.NewMetricsExporter(
+	exp, err := exporterhelper.NewMetricsExporter(
 		&effectiveConfig.ExporterSettings,
 		sender.pushMetricsData,
 		exporterhelper.WithShutdown(sender.Shutdown),
 		exporterhelper.WithTracing(true),
 		exporterhelper.WithMetrics(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return resourcetotelemetry.WrapMetricsExporter(effectiveConfig.ResourceToTelemetrySettings, exp), nil
 }
 
 // carbonSender is the struct tying the translation function and the TCP
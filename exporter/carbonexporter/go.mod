@@ -5,6 +5,9 @@ go 1.13
 require (
 	github.com/census-instrumentation/opencensus-proto v0.2.1
 	github.com/open-telemetry/opentelemetry-collector v0.2.4-0.20200122010738-1a92a7ea5aea
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry v0.0.0
 	github.com/stretchr/testify v1.4.0
 	go.uber.org/zap v1.10.0
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry => ../../internal/resourcetotelemetry
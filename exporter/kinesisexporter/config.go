@@ -19,6 +19,8 @@ import (
 )
 
 // AWSConfig contains AWS specific configuration such as kinesis stream, region, etc.
+// The Kinesis exporter library creates its own AWS session from the region and
+// role, so they can't use the session settings of awsutil.
 type AWSConfig struct {
 	StreamName      string `mapstructure:"stream_name"`
 	KinesisEndpoint string `mapstructure:"kinesis_endpoint"`
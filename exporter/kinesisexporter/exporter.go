@@ -17,46 +17,37 @@ package kinesisexporter
 import (
 	"context"
 
-	"github.com/open-telemetry/opentelemetry-collector/component"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumererror"
-	"github.com/open-telemetry/opentelemetry-collector/exporter"
 	jaegertranslator "github.com/open-telemetry/opentelemetry-collector/translator/trace/jaeger"
 	kinesis "github.com/signalfx/opencensus-go-exporter-kinesis"
 	"go.uber.org/zap"
 )
 
-// Exporter implements an OpenTelemetry trace exporter that exports all spans to AWS Kinesis
+// Exporter exports all spans to AWS Kinesis. It is wrapped by exporterhelper,
+// with pushTraceData as the only entry point for the spans.
 type Exporter struct {
 	kinesis *kinesis.Exporter
 	logger  *zap.Logger
 }
 
-var _ (exporter.TraceExporter) = (*Exporter)(nil)
-
-// Start tells the exporter to start. The exporter may prepare for exporting
-// by connecting to the endpoint. Host parameter can be used for communicating
-// with the host after Start() has already returned. If error is returned by
-// Start() then the collector startup will be aborted.
-func (e Exporter) Start(host component.Host) error {
-	return nil
-}
-
 // Shutdown is invoked during exporter shutdown.
 func (e Exporter) Shutdown() error {
 	e.kinesis.Flush()
 	return nil
 }
 
-// ConsumeTraceData receives a span batch and exports it to AWS Kinesis
-func (e Exporter) ConsumeTraceData(c context.Context, td consumerdata.TraceData) error {
+// pushTraceData exports the span batch to AWS Kinesis and returns the number
+// of spans that could not be exported.
+func (e Exporter) pushTraceData(c context.Context, td consumerdata.TraceData) (int, error) {
 	pBatch, err := jaegertranslator.OCProtoToJaegerProto(td)
 	if err != nil {
 		e.logger.Error("error translating span batch", zap.Error(err))
-		return consumererror.Permanent(err)
+		return len(td.Spans), consumererror.Permanent(err)
 	}
 	// TODO: Use a multi error type
 	var exportErr error
+	droppedSpans := 0
 	for _, span := range pBatch.GetSpans() {
 		if span.Process == nil {
 			span.Process = pBatch.Process
@@ -65,7 +56,8 @@ func (e Exporter) ConsumeTraceData(c context.Context, td consumerdata.TraceData)
 		if err != nil {
 			e.logger.Error("error exporting span to kinesis", zap.Error(err))
 			exportErr = err
+			droppedSpans++
 		}
 	}
-	return exportErr
+	return droppedSpans, exportErr
 }
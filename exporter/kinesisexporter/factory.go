@@ -18,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/config/configerror"
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
 	"github.com/open-telemetry/opentelemetry-collector/exporter"
+	"github.com/open-telemetry/opentelemetry-collector/exporter/exporterhelper"
 	kinesis "github.com/signalfx/opencensus-go-exporter-kinesis"
 	"go.uber.org/zap"
 )
@@ -89,7 +90,13 @@ func (f *Factory) CreateTraceExporter(logger *zap.Logger, cfg configmodels.Expor
 	if err != nil {
 		return nil, err
 	}
-	return Exporter{k, logger}, nil
+	e := Exporter{k, logger}
+	return exporterhelper.NewTraceExporter(
+		c,
+		e.pushTraceData,
+		exporterhelper.WithTracing(true),
+		exporterhelper.WithMetrics(true),
+		exporterhelper.WithShutdown(e.Shutdown))
 }
 
 // CreateMetricsExporter creates a metrics exporter based on this config.
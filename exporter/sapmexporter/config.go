@@ -23,8 +23,9 @@ import (
 )
 
 const (
-	defaultEndpointScheme = "https"
-	defaultNumWorkers     = 8
+	defaultEndpointScheme       = "https"
+	defaultNumWorkers           = 8
+	defaultMaxRequestsPerSecond = 100
 )
 
 // Config defines configuration for SAPM exporter.
@@ -38,12 +39,22 @@ type Config struct {
 	// AccessToken is the authentication token provided by SignalFx.
 	AccessToken string `mapstructure:"access_token"`
 
+	// AccessTokenPassthrough indicates whether to use the access token carried
+	// on the "com.splunk.signalfx.access_token" resource label, if present,
+	// instead of the configured one.
+	AccessTokenPassthrough bool `mapstructure:"access_token_passthrough"`
+
 	// NumWorkers is the number of workers that should be used to export traces.
 	// Exporter can make as many requests in parallel as the number of workers. Defaults to 8.
 	NumWorkers uint `mapstructure:"num_workers"`
 
 	// MaxConnections is used to set a limit to the maximum idle HTTP connection the exporter can keep open.
 	MaxConnections uint `mapstructure:"max_connections"`
+
+	// MaxRequestsPerSecond is the maximum rate of requests sent by each worker.
+	// The rate of a worker is halved each time the endpoint throttles it, and
+	// grows back while its requests go through. Defaults to 100.
+	MaxRequestsPerSecond float64 `mapstructure:"max_requests_per_second"`
 }
 
 func (c *Config) validate() error {
@@ -60,19 +71,33 @@ func (c *Config) validate() error {
 		e.Scheme = defaultEndpointScheme
 	}
 	c.Endpoint = e.String()
+
+	if c.MaxRequestsPerSecond < 0 {
+		return errors.New("`max_requests_per_second` must not be negative")
+	}
 	return nil
 }
 
 func (c *Config) clientOptions() []sapmclient.Option {
-	opts := []sapmclient.Option{
-		sapmclient.WithEndpoint(c.Endpoint),
+	numWorkers := c.NumWorkers
+	if numWorkers == 0 {
+		numWorkers = defaultNumWorkers
+	}
+	maxConnections := c.MaxConnections
+	if maxConnections == 0 {
+		maxConnections = defaultMaxConnections
 	}
-	if c.NumWorkers > 0 {
-		opts = append(opts, sapmclient.WithWorkers(c.NumWorkers))
+	maxRequestsPerSecond := c.MaxRequestsPerSecond
+	if maxRequestsPerSecond == 0 {
+		maxRequestsPerSecond = defaultMaxRequestsPerSecond
 	}
 
-	if c.MaxConnections > 0 {
-		opts = append(opts, sapmclient.WithMaxConnections(c.MaxConnections))
+	opts := []sapmclient.Option{
+		sapmclient.WithEndpoint(c.Endpoint),
+		sapmclient.WithWorkers(numWorkers),
+		// The limit of idle connections is set on the client, the SAPM
+		// client only applies it to the client it creates itself.
+		sapmclient.WithHTTPClient(newHTTPClient(numWorkers, maxConnections, maxRequestsPerSecond)),
 	}
 
 	if c.AccessToken != "" {
@@ -45,10 +45,12 @@ func TestLoadConfig(t *testing.T) {
 	r1 := cfg.Exporters["sapm/customname"].(*Config)
 	assert.Equal(t, r1,
 		&Config{
-			ExporterSettings: configmodels.ExporterSettings{TypeVal: typeStr, NameVal: "sapm/customname"},
-			Endpoint:         "test-endpoint",
-			AccessToken:      "abcd1234",
-			NumWorkers:       3,
-			MaxConnections:   45,
+			ExporterSettings:       configmodels.ExporterSettings{TypeVal: typeStr, NameVal: "sapm/customname"},
+			Endpoint:               "test-endpoint",
+			AccessToken:            "abcd1234",
+			AccessTokenPassthrough: true,
+			NumWorkers:             3,
+			MaxConnections:         45,
+			MaxRequestsPerSecond:   50,
 		})
 }
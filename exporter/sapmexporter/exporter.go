@@ -18,6 +18,9 @@ package sapmexporter
 import (
 	"context"
 
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumererror"
 	"github.com/open-telemetry/opentelemetry-collector/exporter"
@@ -25,12 +28,21 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/translator/trace/jaeger"
 	sapmclient "github.com/signalfx/sapm-proto/client"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr"
 )
 
+// accessTokenLabel is the resource label used to pass an access token along
+// with the data when access_token_passthrough is enabled.
+const accessTokenLabel = "com.splunk.signalfx.access_token"
+
 // sapmExporter is a wrapper struct of SAPM exporter
 type sapmExporter struct {
 	client *sapmclient.Client
 	logger *zap.Logger
+	name   string
+
+	accessTokenPassthrough bool
 }
 
 func (se *sapmExporter) Shutdown() error {
@@ -49,24 +61,59 @@ func newSAPMTraceExporter(cfg *Config, logger *zap.Logger) (exporter.TraceExport
 		return nil, err
 	}
 	se := sapmExporter{
-		client: client,
-		logger: logger,
+		client:                 client,
+		logger:                 logger,
+		name:                   cfg.Name(),
+		accessTokenPassthrough: cfg.AccessTokenPassthrough,
 	}
-	return exporterhelper.NewTraceExporter(
+	exp, err := exporterhelper.NewTraceExporter(
 		cfg,
 		se.pushTraceData,
 		exporterhelper.WithTracing(true),
 		exporterhelper.WithMetrics(true),
 		exporterhelper.WithShutdown(se.Shutdown))
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AccessTokenPassthrough {
+		// Each request can only carry a single token, so split the data per
+		// token before handing it to the exporter.
+		exp = &batchPerTokenExporter{
+			TraceExporter: exp,
+			batcher:       batchperresourceattr.NewBatchPerResourceTraces(accessTokenLabel, exp),
+		}
+	}
+
+	return exp, nil
+}
+
+// batchPerTokenExporter wraps an exporter so each batch it receives only
+// carries spans for a single access token.
+type batchPerTokenExporter struct {
+	exporter.TraceExporter
+	batcher consumer.TraceConsumer
+}
+
+func (e *batchPerTokenExporter) ConsumeTraceData(ctx context.Context, td consumerdata.TraceData) error {
+	return e.batcher.ConsumeTraceData(ctx, td)
 }
 
 func (se *sapmExporter) pushTraceData(ctx context.Context, td consumerdata.TraceData) (int, error) {
+	var accessToken string
+	if se.accessTokenPassthrough {
+		accessToken, td = extractAccessToken(td)
+	}
+
 	jBatch, err := jaeger.OCProtoToJaegerProto(td)
 	if err != nil {
 		return 0, consumererror.Permanent(err)
 	}
-	err = se.client.Export(ctx, jBatch)
+	err = se.client.ExportWithAccessToken(ctx, jBatch, accessToken)
 	if err != nil {
+		if isThrottled(err) {
+			recordThrottledSpans(ctx, se.name, len(td.Spans))
+		}
 		if sendErr, ok := err.(*sapmclient.ErrSend); ok {
 			if sendErr.Permanent {
 				return 0, consumererror.Permanent(sendErr)
@@ -76,3 +123,48 @@ func (se *sapmExporter) pushTraceData(ctx context.Context, td consumerdata.Trace
 	}
 	return len(td.Spans), nil
 }
+
+// extractAccessToken returns the access token carried by the batch, if any,
+// and a copy of the batch without the token label, on the batch resource and
+// on the resources of the spans, so it is not exported as a tag. The batch is
+// expected to carry a single token, see batchPerTokenExporter.
+func extractAccessToken(td consumerdata.TraceData) (string, consumerdata.TraceData) {
+	if len(td.Spans) == 0 {
+		return "", td
+	}
+
+	accessToken := batchperresourceattr.AttributeValue(
+		accessTokenLabel, td.Resource, td.Spans[0].GetResource())
+
+	td.Resource = withoutAccessToken(td.Resource)
+	spans := make([]*tracepb.Span, len(td.Spans))
+	for i, span := range td.Spans {
+		if resource := withoutAccessToken(span.GetResource()); resource != span.GetResource() {
+			sp := *span
+			sp.Resource = resource
+			span = &sp
+		}
+		spans[i] = span
+	}
+	td.Spans = spans
+
+	return accessToken, td
+}
+
+// withoutAccessToken returns a copy of the resource without the token label,
+// or the resource itself if it has none.
+func withoutAccessToken(resource *resourcepb.Resource) *resourcepb.Resource {
+	if _, ok := resource.GetLabels()[accessTokenLabel]; !ok {
+		return resource
+	}
+	labels := make(map[string]string, len(resource.Labels)-1)
+	for k, v := range resource.Labels {
+		if k != accessTokenLabel {
+			labels[k] = v
+		}
+	}
+	return &resourcepb.Resource{
+		Type:   resource.Type,
+		Labels: labels,
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sapmexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/signalfx/sapm-proto/sapmprotocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+)
+
+// tokenRecorder is a SAPM endpoint that records, for each request, the
+// access token it carried and the number of spans in it. It also records
+// whether the token label was exported as a tag.
+type tokenRecorder struct {
+	t        *testing.T
+	mu       sync.Mutex
+	requests []receivedRequest
+	leaked   bool
+}
+
+type receivedRequest struct {
+	token string
+	spans int
+}
+
+func (tr *tokenRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sapm, err := sapmprotocol.ParseTraceV2Request(r)
+	if !assert.NoError(tr.t, err) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	spans := 0
+	leaked := false
+	for _, batch := range sapm.Batches {
+		spans += len(batch.Spans)
+		if batch.Process != nil {
+			for _, tag := range batch.Process.Tags {
+				leaked = leaked || tag.Key == accessTokenLabel
+			}
+		}
+		for _, span := range batch.Spans {
+			for _, tag := range span.Tags {
+				leaked = leaked || tag.Key == accessTokenLabel
+			}
+			if span.Process != nil {
+				for _, tag := range span.Process.Tags {
+					leaked = leaked || tag.Key == accessTokenLabel
+				}
+			}
+		}
+	}
+
+	tr.mu.Lock()
+	tr.leaked = tr.leaked || leaked
+	tr.requests = append(tr.requests, receivedRequest{
+		token: r.Header.Get("X-SF-Token"),
+		spans: spans,
+	})
+	tr.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (tr *tokenRecorder) received() []receivedRequest {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return append([]receivedRequest(nil), tr.requests...)
+}
+
+func (tr *tokenRecorder) tokenLeaked() bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.leaked
+}
+
+func spanWithToken(id byte, token string) *tracepb.Span {
+	span := &tracepb.Span{
+		TraceId: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanId:  []byte{0, 0, 0, 0, 0, 0, 0, id},
+		Name:    &tracepb.TruncatableString{Value: "span"},
+	}
+	if token != "" {
+		span.Resource = &resourcepb.Resource{
+			Labels: map[string]string{
+				accessTokenLabel: token,
+				"host.name":      "h0",
+			},
+		}
+	}
+	return span
+}
+
+func TestAccessTokenPassthrough(t *testing.T) {
+	tests := []struct {
+		name        string
+		passthrough bool
+		resource    *resourcepb.Resource
+		spans       []*tracepb.Span
+		want        []receivedRequest
+	}{
+		{
+			name:        "passthrough disabled",
+			passthrough: false,
+			spans: []*tracepb.Span{
+				spanWithToken(1, "token-a"),
+				spanWithToken(2, "token-b"),
+			},
+			want: []receivedRequest{
+				{token: "configured", spans: 2},
+			},
+		},
+		{
+			name:        "single token",
+			passthrough: true,
+			spans: []*tracepb.Span{
+				spanWithToken(1, "token-a"),
+				spanWithToken(2, "token-a"),
+			},
+			want: []receivedRequest{
+				{token: "token-a", spans: 2},
+			},
+		},
+		{
+			name:        "split per token",
+			passthrough: true,
+			spans: []*tracepb.Span{
+				spanWithToken(1, "token-a"),
+				spanWithToken(2, "token-b"),
+				spanWithToken(3, "token-a"),
+				spanWithToken(4, ""),
+			},
+			want: []receivedRequest{
+				{token: "token-a", spans: 2},
+				{token: "token-b", spans: 1},
+				{token: "configured", spans: 1},
+			},
+		},
+		{
+			name:        "span token overrides batch token",
+			passthrough: true,
+			resource: &resourcepb.Resource{
+				Labels: map[string]string{accessTokenLabel: "token-batch"},
+			},
+			spans: []*tracepb.Span{
+				spanWithToken(1, "token-a"),
+				spanWithToken(2, ""),
+			},
+			want: []receivedRequest{
+				{token: "token-a", spans: 1},
+				{token: "token-batch", spans: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &tokenRecorder{t: t}
+			server := httptest.NewServer(recorder)
+			defer server.Close()
+
+			cfg := &Config{
+				Endpoint:               server.URL + sapmprotocol.TraceEndpointV2,
+				AccessToken:            "configured",
+				AccessTokenPassthrough: tt.passthrough,
+				NumWorkers:             1,
+			}
+			exp, err := newSAPMTraceExporter(cfg, zap.NewNop())
+			require.NoError(t, err)
+			defer exp.Shutdown()
+
+			td := consumerdata.TraceData{Resource: tt.resource, Spans: tt.spans}
+			require.NoError(t, exp.ConsumeTraceData(context.Background(), td))
+
+			assert.Equal(t, tt.want, recorder.received())
+			if tt.passthrough {
+				assert.False(t, recorder.tokenLeaked())
+			}
+		})
+	}
+}
+
+func TestExtractAccessToken(t *testing.T) {
+	td := consumerdata.TraceData{
+		Resource: &resourcepb.Resource{
+			Type: "test",
+			Labels: map[string]string{
+				accessTokenLabel: "token-a",
+				"k0":             "v0",
+			},
+		},
+		Spans: []*tracepb.Span{
+			spanWithToken(1, "token-a"),
+			spanWithToken(2, ""),
+		},
+	}
+
+	accessToken, got := extractAccessToken(td)
+	assert.Equal(t, "token-a", accessToken)
+	assert.Equal(t, &resourcepb.Resource{Type: "test", Labels: map[string]string{"k0": "v0"}}, got.Resource)
+	assert.Equal(t, &resourcepb.Resource{Labels: map[string]string{"host.name": "h0"}}, got.Spans[0].Resource)
+	assert.Nil(t, got.Spans[1].Resource)
+	// The original batch must not be modified.
+	assert.Equal(t, "token-a", td.Resource.Labels[accessTokenLabel])
+	assert.Equal(t, "token-a", td.Spans[0].Resource.Labels[accessTokenLabel])
+}
+
+func TestThrottledSpans(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		ExporterSettings: configmodels.ExporterSettings{TypeVal: typeStr, NameVal: "sapm/throttled"},
+		Endpoint:         server.URL + sapmprotocol.TraceEndpointV2,
+		NumWorkers:       1,
+	}
+	exp, err := newSAPMTraceExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer exp.Shutdown()
+
+	td := consumerdata.TraceData{Spans: []*tracepb.Span{spanWithToken(1, ""), spanWithToken(2, "")}}
+	assert.Error(t, exp.ConsumeTraceData(context.Background(), td))
+
+	rows, err := view.RetrieveData(viewThrottledSpans.Name)
+	require.NoError(t, err)
+	var throttled int64
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Value == "sapm/throttled" {
+				throttled += int64(row.Data.(*view.SumData).Value)
+			}
+		}
+	}
+	assert.Equal(t, int64(2), throttled)
+}
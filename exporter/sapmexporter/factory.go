@@ -42,7 +42,8 @@ func (f *Factory) CreateDefaultConfig() configmodels.Exporter {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
-		NumWorkers: defaultNumWorkers,
+		NumWorkers:           defaultNumWorkers,
+		MaxRequestsPerSecond: defaultMaxRequestsPerSecond,
 	}
 }
 
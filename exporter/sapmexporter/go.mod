@@ -3,12 +3,17 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sapmex
 go 1.12
 
 require (
+	github.com/census-instrumentation/opencensus-proto v0.2.1
 	github.com/open-telemetry/opentelemetry-collector v0.2.5
-	github.com/signalfx/sapm-proto v0.3.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr v0.0.0
+	github.com/signalfx/sapm-proto v0.4.0
 	github.com/stretchr/testify v1.4.0
+	go.opencensus.io v0.22.2
 	go.uber.org/atomic v1.5.1 // indirect
 	go.uber.org/multierr v1.4.0 // indirect
 	go.uber.org/zap v1.13.0
 	google.golang.org/genproto v0.0.0-20191115221424-83cc0476cb11 // indirect
 	google.golang.org/grpc v1.23.1 // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr => ../../internal/batchperresourceattr
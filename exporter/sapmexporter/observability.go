@@ -0,0 +1,47 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sapmexporter
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-collector/observability"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func init() {
+	view.Register(viewThrottledSpans)
+}
+
+var mThrottledSpans = stats.Int64("otelcol/sapm/throttled_spans", "Number of spans in requests throttled by the SAPM endpoint", "1")
+
+var viewThrottledSpans = &view.View{
+	Name:        mThrottledSpans.Name(),
+	Description: mThrottledSpans.Description(),
+	Measure:     mThrottledSpans,
+	TagKeys:     []tag.Key{observability.TagKeyExporter},
+	Aggregation: view.Sum(),
+}
+
+// recordThrottledSpans records the spans of a throttled request against the
+// given exporter.
+func recordThrottledSpans(ctx context.Context, exporterName string, numSpans int) {
+	stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(observability.TagKeyExporter, exporterName)},
+		mThrottledSpans.M(int64(numSpans)))
+}
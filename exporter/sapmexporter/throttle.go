@@ -0,0 +1,244 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sapmexporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	sapmclient "github.com/signalfx/sapm-proto/client"
+)
+
+const (
+	defaultMaxConnections = 100
+	defaultHTTPTimeout    = 10 * time.Second
+
+	// minRequestsPerSecond is the rate below which a throttled worker isn't
+	// slowed down any further.
+	minRequestsPerSecond = 0.1
+	// recoverySteps is the number of requests going through, without being
+	// throttled, needed for a worker to recover its maximum rate.
+	recoverySteps = 20
+)
+
+// errThrottled is returned by the transport when the SAPM endpoint responds
+// with 429 Too Many Requests.
+var errThrottled = errors.New("the SAPM endpoint throttled the request (429 Too Many Requests)")
+
+// newHTTPClient returns the HTTP client used by the SAPM client. Its settings
+// are the ones of http.DefaultTransport and its requests are rate limited by
+// a throttlingTransport.
+func newHTTPClient(numWorkers, maxConnections uint, maxRequestsPerSecond float64) *http.Client {
+	base := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          int(maxConnections),
+		MaxIdleConnsPerHost:   int(maxConnections),
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	return &http.Client{
+		Timeout:   defaultHTTPTimeout,
+		Transport: newThrottlingTransport(base, numWorkers, maxRequestsPerSecond),
+	}
+}
+
+// throttlingTransport rate limits the requests of the SAPM client workers
+// with a token bucket per worker. The SAPM client never sends more requests
+// at once than it has workers, so each request in flight holds one of the
+// buckets.
+type throttlingTransport struct {
+	base     http.RoundTripper
+	limiters chan *rateLimiter
+}
+
+func newThrottlingTransport(base http.RoundTripper, numWorkers uint, maxRequestsPerSecond float64) *throttlingTransport {
+	t := &throttlingTransport{
+		base:     base,
+		limiters: make(chan *rateLimiter, numWorkers),
+	}
+	now := time.Now()
+	for i := uint(0); i < numWorkers; i++ {
+		t.limiters <- newRateLimiter(maxRequestsPerSecond, now)
+	}
+	return t
+}
+
+func (t *throttlingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := <-t.limiters
+	defer func() {
+		t.limiters <- limiter
+	}()
+
+	if err := limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		limiter.succeeded()
+		return resp, nil
+	}
+
+	now := time.Now()
+	limiter.throttled(now, retryAfter(resp.Header, now))
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return nil, errThrottled
+}
+
+// retryAfter returns the delay requested by the Retry-After header, zero if
+// there is none.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// isThrottled returns whether the export failed because the SAPM endpoint
+// throttled the request.
+func isThrottled(err error) bool {
+	for {
+		switch e := err.(type) {
+		case *sapmclient.ErrSend:
+			err = e.Err
+		case *url.Error:
+			err = e.Err
+		default:
+			return err == errThrottled
+		}
+	}
+}
+
+// rateLimiter is the token bucket of a single worker. Its rate is halved each
+// time the worker is throttled, and grows back to the maximum rate while the
+// requests go through.
+type rateLimiter struct {
+	mu          sync.Mutex
+	maxRate     float64
+	rate        float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(maxRate float64, now time.Time) *rateLimiter {
+	return &rateLimiter{
+		maxRate: maxRate,
+		rate:    maxRate,
+		tokens:  math.Max(1, maxRate),
+		last:    now,
+	}
+}
+
+// wait blocks until the worker is allowed to send a request.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long the worker must wait before
+// using it.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	l.tokens--
+
+	var delay time.Duration
+	if l.pausedUntil.After(now) {
+		delay = l.pausedUntil.Sub(now)
+	}
+	if l.tokens < 0 {
+		delay += time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	return delay
+}
+
+// throttled slows the worker down after the endpoint throttled one of its
+// requests. No token is given to the worker before retryAfter elapsed.
+func (l *rateLimiter) throttled(now time.Time, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	l.rate = math.Max(l.rate/2, minRequestsPerSecond)
+	if l.tokens > 0 {
+		l.tokens = 0
+	}
+	if until := now.Add(retryAfter); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// succeeded lets the worker speed up again after a request went through.
+func (l *rateLimiter) succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate = math.Min(l.rate+l.maxRate/recoverySteps, l.maxRate)
+}
+
+// refill adds the tokens earned since the last refill. No tokens are earned
+// while the worker is paused.
+func (l *rateLimiter) refill(now time.Time) {
+	from := l.last
+	if l.pausedUntil.After(from) {
+		from = l.pausedUntil
+	}
+	if now.After(from) {
+		l.tokens = math.Min(l.tokens+now.Sub(from).Seconds()*l.rate, math.Max(1, l.rate))
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+}
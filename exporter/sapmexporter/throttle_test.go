@@ -0,0 +1,132 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sapmexporter
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sapmclient "github.com/signalfx/sapm-proto/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	start := time.Unix(1000, 0)
+	l := newRateLimiter(2, start)
+
+	// The bucket starts full.
+	assert.Equal(t, time.Duration(0), l.reserve(start))
+	assert.Equal(t, time.Duration(0), l.reserve(start))
+	assert.Equal(t, 500*time.Millisecond, l.reserve(start))
+
+	// Tokens are earned at the configured rate.
+	now := start.Add(time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+
+	// Being throttled halves the rate and pauses the worker.
+	l.throttled(now, 3*time.Second)
+	assert.Equal(t, 1.0, l.rate)
+	assert.Equal(t, 4*time.Second, l.reserve(now))
+
+	// No tokens are earned while paused.
+	now = now.Add(3 * time.Second)
+	assert.Equal(t, 2*time.Second, l.reserve(now))
+
+	// The rate grows back up to the maximum.
+	for i := 0; i < recoverySteps; i++ {
+		l.succeeded()
+	}
+	assert.Equal(t, 2.0, l.rate)
+}
+
+func TestRateLimiterMinimumRate(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := newRateLimiter(1, now)
+	for i := 0; i < 10; i++ {
+		l.throttled(now, 0)
+	}
+	assert.Equal(t, minRequestsPerSecond, l.rate)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: 0},
+		{name: "seconds", value: "7", want: 7 * time.Second},
+		{name: "date", value: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute},
+		{name: "invalid", value: "soon", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			assert.Equal(t, tt.want, retryAfter(header, now))
+		})
+	}
+}
+
+func TestIsThrottled(t *testing.T) {
+	assert.True(t, isThrottled(errThrottled))
+	assert.True(t, isThrottled(&sapmclient.ErrSend{
+		Err: &url.Error{Op: "Post", URL: "http://localhost", Err: errThrottled},
+	}))
+	assert.False(t, isThrottled(&sapmclient.ErrSend{Err: errors.New("connection refused")}))
+	assert.False(t, isThrottled(nil))
+}
+
+func TestThrottlingTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newThrottlingTransport(http.DefaultTransport, 1, 100)
+	client := &http.Client{Transport: transport}
+
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+	assert.True(t, isThrottled(err))
+
+	limiter := <-transport.limiters
+	assert.Equal(t, 50.0, limiter.rate)
+	transport.limiters <- limiter
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	limiter = <-transport.limiters
+	assert.Equal(t, 55.0, limiter.rate)
+	transport.limiters <- limiter
+}
@@ -0,0 +1,104 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxcorrelationexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for SignalFx correlation exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// AccessToken is the authentication token provided by SignalFx.
+	AccessToken string `mapstructure:"access_token"`
+
+	// Realm is the SignalFx realm where the correlations are sent to. The
+	// default value is "us0".
+	Realm string `mapstructure:"realm"`
+
+	// Endpoint is the base URL of the SignalFx API, eg.:
+	// "https://api.us0.signalfx.com". The value of Realm is ignored if the
+	// endpoint is specified.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Timeout is the maximum timeout for each correlation request. The default
+	// value is 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// HostDimensions are the resource labels identifying the infrastructure
+	// running the services, eg.: the host name or the pod. A correlation is
+	// created between the service and each of these labels found on the
+	// resource of the spans. The default value is ["host.hostname"].
+	HostDimensions []string `mapstructure:"host_dimensions"`
+
+	// SyncInterval is how often the correlations are checked for stale
+	// services. The default value is 1 minute.
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+
+	// StaleServiceTimeout is how long a service can go without sending spans
+	// from a host before their correlation is deleted. The default value is 5
+	// minutes.
+	StaleServiceTimeout time.Duration `mapstructure:"stale_service_timeout"`
+
+	// MaxRetries is the number of times a failed correlation request is
+	// retried. The default value is 2.
+	MaxRetries uint `mapstructure:"max_retries"`
+
+	// RetryDelay is the time to wait before retrying a failed correlation
+	// request. The default value is 5 seconds.
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+
+	// MaxBufferedRequests is the maximum number of correlation requests
+	// waiting to be sent. New requests are dropped when the limit is reached.
+	// The default value is 1000.
+	MaxBufferedRequests uint `mapstructure:"max_buffered_requests"`
+}
+
+func (c *Config) validate() error {
+	if c.AccessToken == "" {
+		return errors.New("`access_token` not specified")
+	}
+	if c.Realm == "" && c.Endpoint == "" {
+		return errors.New("`realm` or `endpoint` must be specified")
+	}
+	if len(c.HostDimensions) == 0 {
+		return errors.New("`host_dimensions` must not be empty")
+	}
+	if c.Timeout <= 0 || c.SyncInterval <= 0 || c.StaleServiceTimeout <= 0 || c.RetryDelay <= 0 {
+		return errors.New("`timeout`, `sync_interval`, `stale_service_timeout` and `retry_delay` must be positive")
+	}
+	if c.MaxBufferedRequests == 0 {
+		return errors.New("`max_buffered_requests` must be positive")
+	}
+	return nil
+}
+
+// apiURL returns the base URL of the SignalFx API.
+func (c *Config) apiURL() (*url.URL, error) {
+	if c.Endpoint == "" {
+		return url.Parse(fmt.Sprintf("https://api.%s.signalfx.com", c.Realm))
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `endpoint`: %v", err)
+	}
+	return u, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxcorrelationexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := config.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := &Factory{}
+	factories.Exporters[typeStr] = factory
+	cfg, err := config.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["signalfx_correlation"]
+	assert.Equal(t, factory.CreateDefaultConfig(), e0)
+
+	expectedName := "signalfx_correlation/allsettings"
+	e1 := cfg.Exporters[expectedName]
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: expectedName,
+		},
+		AccessToken:         "abcd1234",
+		Realm:               "us0",
+		Endpoint:            "https://api.example.com",
+		Timeout:             10 * time.Second,
+		HostDimensions:      []string{"host.hostname", "k8s.pod.name"},
+		SyncInterval:        30 * time.Second,
+		StaleServiceTimeout: 10 * time.Minute,
+		MaxRetries:          5,
+		RetryDelay:          time.Second,
+		MaxBufferedRequests: 100,
+	}, e1)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:    "no_access_token",
+			modify:  func(cfg *Config) { cfg.AccessToken = "" },
+			wantErr: true,
+		},
+		{
+			name:    "no_realm_nor_endpoint",
+			modify:  func(cfg *Config) { cfg.Realm = "" },
+			wantErr: true,
+		},
+		{
+			name:    "no_host_dimensions",
+			modify:  func(cfg *Config) { cfg.HostDimensions = nil },
+			wantErr: true,
+		},
+		{
+			name:    "zero_sync_interval",
+			modify:  func(cfg *Config) { cfg.SyncInterval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "zero_max_buffered_requests",
+			modify:  func(cfg *Config) { cfg.MaxBufferedRequests = 0 },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+			cfg.AccessToken = "abcd1234"
+			tt.modify(cfg)
+			err := cfg.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigAPIURL(t *testing.T) {
+	cfg := &Config{Realm: "us1"}
+	u, err := cfg.apiURL()
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.us1.signalfx.com", u.String())
+
+	cfg.Endpoint = "http://localhost:8080"
+	u, err = cfg.apiURL()
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", u.String())
+}
@@ -0,0 +1,108 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxcorrelationexporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const accessTokenHeader = "X-Sf-Token"
+
+// correlation links a service to the value of an infrastructure dimension,
+// eg.: the host running it.
+type correlation struct {
+	dimName  string
+	dimValue string
+	service  string
+}
+
+type correlationOp int
+
+const (
+	opCorrelate correlationOp = iota
+	opDelete
+)
+
+func (op correlationOp) String() string {
+	if op == opDelete {
+		return "delete"
+	}
+	return "correlate"
+}
+
+// correlationRequest is a change to be sent to the correlation API.
+type correlationRequest struct {
+	op correlationOp
+	correlation
+}
+
+// correlationSender sends correlation requests to the backend.
+type correlationSender interface {
+	send(ctx context.Context, req correlationRequest) error
+}
+
+// httpCorrelationSender sends the correlation requests to the SignalFx API.
+type httpCorrelationSender struct {
+	baseURL     string
+	accessToken string
+	client      *http.Client
+}
+
+var _ correlationSender = (*httpCorrelationSender)(nil)
+
+func (s *httpCorrelationSender) send(ctx context.Context, req correlationRequest) error {
+	// The dimension values can contain "/", so each path segment is escaped.
+	reqURL := strings.TrimSuffix(s.baseURL, "/") + "/v2/apm/correlate/" +
+		url.PathEscape(req.dimName) + "/" + url.PathEscape(req.dimValue) + "/service"
+
+	var (
+		method string
+		body   io.Reader
+	)
+	switch req.op {
+	case opCorrelate:
+		method = http.MethodPut
+		body = strings.NewReader(req.service)
+	case opDelete:
+		method = http.MethodDelete
+		reqURL += "/" + url.PathEscape(req.service)
+	}
+
+	httpReq, err := http.NewRequest(method, reqURL, body)
+	if err != nil {
+		return err
+	}
+	httpReq = httpReq.WithContext(ctx)
+	httpReq.Header.Set("Content-Type", "text/plain")
+	httpReq.Header.Set(accessTokenHeader, s.accessToken)
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return nil
+}
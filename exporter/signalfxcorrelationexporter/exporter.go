@@ -0,0 +1,117 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxcorrelationexporter
+
+import (
+	"context"
+	"net/http"
+
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/exporter"
+	"github.com/open-telemetry/opentelemetry-collector/exporter/exporterhelper"
+	"github.com/open-telemetry/opentelemetry-collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+// correlationExporter extracts the services and the infrastructure running
+// them from the spans and keeps their correlations up to date. The spans
+// themselves are not exported.
+type correlationExporter struct {
+	hostDimensions []string
+	tracker        *tracker
+}
+
+func newCorrelationExporter(cfg *Config, logger *zap.Logger) (exporter.TraceExporter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	apiURL, err := cfg.apiURL()
+	if err != nil {
+		return nil, err
+	}
+
+	sender := &httpCorrelationSender{
+		baseURL:     apiURL.String(),
+		accessToken: cfg.AccessToken,
+		client:      &http.Client{Timeout: cfg.Timeout},
+	}
+	ce := &correlationExporter{
+		hostDimensions: cfg.HostDimensions,
+		tracker:        newTracker(cfg, sender, logger),
+	}
+
+	exp, err := exporterhelper.NewTraceExporter(
+		cfg,
+		ce.pushTraceData,
+		exporterhelper.WithTracing(true),
+		exporterhelper.WithMetrics(true),
+		exporterhelper.WithShutdown(ce.Shutdown))
+	if err != nil {
+		return nil, err
+	}
+
+	ce.tracker.start()
+	return exp, nil
+}
+
+// Shutdown stops the background correlation updates.
+func (ce *correlationExporter) Shutdown() error {
+	ce.tracker.shutdown()
+	return nil
+}
+
+func (ce *correlationExporter) pushTraceData(ctx context.Context, td consumerdata.TraceData) (int, error) {
+	batchService := td.Node.GetServiceInfo().GetName()
+
+	// Spans of the same batch typically share the resource, only process
+	// each of them once.
+	seen := make(map[*resourcepb.Resource]struct{})
+	for _, span := range td.Spans {
+		resource := td.Resource
+		if span.GetResource() != nil {
+			resource = span.Resource
+		}
+		if _, ok := seen[resource]; ok {
+			continue
+		}
+		seen[resource] = struct{}{}
+		ce.observe(batchService, resource)
+	}
+
+	return 0, nil
+}
+
+func (ce *correlationExporter) observe(batchService string, resource *resourcepb.Resource) {
+	labels := resource.GetLabels()
+	service := labels[conventions.AttributeServiceName]
+	if service == "" {
+		service = batchService
+	}
+	if service == "" {
+		return
+	}
+
+	for _, dimName := range ce.hostDimensions {
+		if dimValue := labels[dimName]; dimValue != "" {
+			ce.tracker.observe(correlation{
+				dimName:  dimName,
+				dimValue: dimValue,
+				service:  service,
+			})
+		}
+	}
+}
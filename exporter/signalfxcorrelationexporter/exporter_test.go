@@ -0,0 +1,132 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxcorrelationexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type receivedRequest struct {
+	method string
+	path   string
+	body   string
+	token  string
+}
+
+func TestCorrelationExporter(t *testing.T) {
+	var mu sync.Mutex
+	var received []receivedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedRequest{
+			method: r.Method,
+			path:   r.URL.EscapedPath(),
+			body:   string(body),
+			token:  r.Header.Get(accessTokenHeader),
+		})
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.AccessToken = "abcd1234"
+	cfg.Endpoint = server.URL
+	cfg.HostDimensions = []string{"host.hostname", "k8s.pod.name"}
+	cfg.SyncInterval = 10 * time.Millisecond
+	cfg.StaleServiceTimeout = 100 * time.Millisecond
+
+	exp, err := newCorrelationExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer exp.Shutdown()
+
+	td := consumerdata.TraceData{
+		Node: &commonpb.Node{
+			ServiceInfo: &commonpb.ServiceInfo{Name: "batch-service"},
+		},
+		Resource: &resourcepb.Resource{
+			Labels: map[string]string{"host.hostname": "host-0"},
+		},
+		Spans: []*tracepb.Span{
+			{},
+			{},
+			{
+				Resource: &resourcepb.Resource{
+					Labels: map[string]string{
+						"service.name":  "span-service",
+						"host.hostname": "host-1",
+						"k8s.pod.name":  "pod/1",
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, exp.ConsumeTraceData(context.Background(), td))
+
+	wantCorrelations := []receivedRequest{
+		{http.MethodPut, "/v2/apm/correlate/host.hostname/host-0/service", "batch-service", "abcd1234"},
+		{http.MethodPut, "/v2/apm/correlate/host.hostname/host-1/service", "span-service", "abcd1234"},
+		{http.MethodPut, "/v2/apm/correlate/k8s.pod.name/pod%2F1/service", "span-service", "abcd1234"},
+	}
+	wantDeletes := []receivedRequest{
+		{http.MethodDelete, "/v2/apm/correlate/host.hostname/host-0/service/batch-service", "", "abcd1234"},
+		{http.MethodDelete, "/v2/apm/correlate/host.hostname/host-1/service/span-service", "", "abcd1234"},
+		{http.MethodDelete, "/v2/apm/correlate/k8s.pod.name/pod%2F1/service/span-service", "", "abcd1234"},
+	}
+
+	// The correlations are created once and deleted after going stale.
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == len(wantCorrelations)+len(wantDeletes)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, len(wantCorrelations)+len(wantDeletes))
+	assert.ElementsMatch(t, wantCorrelations, received[:len(wantCorrelations)])
+	assert.ElementsMatch(t, wantDeletes, received[len(wantCorrelations):])
+}
+
+func TestCorrelationExporterNoService(t *testing.T) {
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.AccessToken = "abcd1234"
+	exp, err := newCorrelationExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer exp.Shutdown()
+
+	td := consumerdata.TraceData{
+		Resource: &resourcepb.Resource{
+			Labels: map[string]string{"host.hostname": "host-0"},
+		},
+		Spans: []*tracepb.Span{{}},
+	}
+	require.NoError(t, exp.ConsumeTraceData(context.Background(), td))
+}
@@ -0,0 +1,10 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxcorrelationexporter
+
+go 1.12
+
+require (
+	github.com/census-instrumentation/opencensus-proto v0.2.1
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/stretchr/testify v1.5.1
+	go.uber.org/zap v1.13.0
+)
@@ -0,0 +1,187 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxcorrelationexporter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// tracker keeps the last time each correlation was observed, it sends a
+// request to create the correlation the first time it is observed and one
+// to delete it once it goes stale.
+type tracker struct {
+	logger *zap.Logger
+	sender correlationSender
+
+	timeout             time.Duration
+	syncInterval        time.Duration
+	staleServiceTimeout time.Duration
+	maxRetries          uint
+	retryDelay          time.Duration
+
+	mu       sync.Mutex
+	lastSeen map[correlation]time.Time
+
+	requests chan correlationRequest
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	// now is used instead of time.Now so tests can control the time.
+	now func() time.Time
+}
+
+func newTracker(cfg *Config, sender correlationSender, logger *zap.Logger) *tracker {
+	return &tracker{
+		logger:              logger,
+		sender:              sender,
+		timeout:             cfg.Timeout,
+		syncInterval:        cfg.SyncInterval,
+		staleServiceTimeout: cfg.StaleServiceTimeout,
+		maxRetries:          cfg.MaxRetries,
+		retryDelay:          cfg.RetryDelay,
+		lastSeen:            make(map[correlation]time.Time),
+		requests:            make(chan correlationRequest, cfg.MaxBufferedRequests),
+		done:                make(chan struct{}),
+		now:                 time.Now,
+	}
+}
+
+// start launches the goroutines sending the requests and expiring the
+// stale correlations.
+func (t *tracker) start() {
+	t.wg.Add(2)
+	go t.runSender()
+	go t.runSync()
+}
+
+// shutdown stops the tracker, requests not sent yet are discarded.
+func (t *tracker) shutdown() {
+	close(t.done)
+	t.wg.Wait()
+}
+
+// observe records that the correlation was seen now.
+func (t *tracker) observe(c correlation) {
+	t.mu.Lock()
+	_, known := t.lastSeen[c]
+	t.lastSeen[c] = t.now()
+	t.mu.Unlock()
+
+	if !known {
+		t.enqueue(correlationRequest{op: opCorrelate, correlation: c})
+	}
+}
+
+// expireStale deletes the correlations not observed within the stale service
+// timeout.
+func (t *tracker) expireStale() {
+	now := t.now()
+	var stale []correlation
+	t.mu.Lock()
+	for c, seen := range t.lastSeen {
+		if now.Sub(seen) > t.staleServiceTimeout {
+			delete(t.lastSeen, c)
+			stale = append(stale, c)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, c := range stale {
+		t.enqueue(correlationRequest{op: opDelete, correlation: c})
+	}
+}
+
+func (t *tracker) enqueue(req correlationRequest) {
+	select {
+	case t.requests <- req:
+	default:
+		if req.op == opCorrelate {
+			t.forget(req.correlation)
+		}
+		t.logger.Warn(
+			"Dropping correlation request, too many requests buffered",
+			zap.Stringer("op", req.op),
+			zap.String("service", req.service),
+			zap.String("dimension", req.dimName))
+	}
+}
+
+// forget removes a correlation that could not be created so it is tried
+// again the next time it is observed.
+func (t *tracker) forget(c correlation) {
+	t.mu.Lock()
+	delete(t.lastSeen, c)
+	t.mu.Unlock()
+}
+
+func (t *tracker) runSync() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.expireStale()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *tracker) runSender() {
+	defer t.wg.Done()
+	for {
+		select {
+		case req := <-t.requests:
+			t.sendWithRetry(req)
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *tracker) sendWithRetry(req correlationRequest) {
+	for attempt := uint(0); ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+		err := t.sender.send(ctx, req)
+		cancel()
+		if err == nil {
+			return
+		}
+
+		if attempt >= t.maxRetries {
+			if req.op == opCorrelate {
+				t.forget(req.correlation)
+			}
+			t.logger.Error(
+				"Failed to send correlation request",
+				zap.Stringer("op", req.op),
+				zap.String("service", req.service),
+				zap.String("dimension", req.dimName),
+				zap.Error(err))
+			return
+		}
+
+		select {
+		case <-time.After(t.retryDelay):
+		case <-t.done:
+			return
+		}
+	}
+}
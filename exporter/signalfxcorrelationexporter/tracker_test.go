@@ -0,0 +1,119 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxcorrelationexporter
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeSender fails the first failures requests it receives.
+type fakeSender struct {
+	mu       sync.Mutex
+	failures int
+	attempts []correlationRequest
+	sent     []correlationRequest
+}
+
+func (s *fakeSender) send(ctx context.Context, req correlationRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts = append(s.attempts, req)
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("failed")
+	}
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func newTestTracker(sender correlationSender) *tracker {
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.RetryDelay = time.Millisecond
+	cfg.MaxBufferedRequests = 2
+	return newTracker(cfg, sender, zap.NewNop())
+}
+
+func TestTrackerObserve(t *testing.T) {
+	tr := newTestTracker(&fakeSender{})
+	c := correlation{dimName: "host.hostname", dimValue: "host-0", service: "svc"}
+
+	tr.observe(c)
+	tr.observe(c)
+	assert.Len(t, tr.requests, 1)
+	assert.Equal(t, correlationRequest{op: opCorrelate, correlation: c}, <-tr.requests)
+}
+
+func TestTrackerExpireStale(t *testing.T) {
+	tr := newTestTracker(&fakeSender{})
+	now := time.Now()
+	tr.now = func() time.Time { return now }
+
+	stale := correlation{dimName: "host.hostname", dimValue: "host-0", service: "svc"}
+	active := correlation{dimName: "host.hostname", dimValue: "host-1", service: "svc"}
+	tr.observe(stale)
+	<-tr.requests
+
+	now = now.Add(tr.staleServiceTimeout)
+	tr.observe(active)
+	<-tr.requests
+
+	now = now.Add(time.Second)
+	tr.expireStale()
+	assert.Len(t, tr.requests, 1)
+	assert.Equal(t, correlationRequest{op: opDelete, correlation: stale}, <-tr.requests)
+	assert.Equal(t, map[correlation]time.Time{active: now.Add(-time.Second)}, tr.lastSeen)
+}
+
+func TestTrackerBufferFull(t *testing.T) {
+	tr := newTestTracker(&fakeSender{})
+	for i := 0; i < 3; i++ {
+		tr.observe(correlation{dimName: "host.hostname", dimValue: strconv.Itoa(i), service: "svc"})
+	}
+
+	// The last correlation didn't fit in the buffer so it is forgotten to be
+	// tried again the next time it is observed.
+	assert.Len(t, tr.requests, 2)
+	assert.Len(t, tr.lastSeen, 2)
+}
+
+func TestTrackerSendWithRetry(t *testing.T) {
+	c := correlation{dimName: "host.hostname", dimValue: "host-0", service: "svc"}
+	req := correlationRequest{op: opCorrelate, correlation: c}
+
+	sender := &fakeSender{failures: 2}
+	tr := newTestTracker(sender)
+	tr.observe(c)
+	tr.sendWithRetry(<-tr.requests)
+	assert.Len(t, sender.attempts, 3)
+	assert.Equal(t, []correlationRequest{req}, sender.sent)
+	assert.Len(t, tr.lastSeen, 1)
+
+	// Once retries are exhausted the correlation is forgotten.
+	sender = &fakeSender{failures: 3}
+	tr = newTestTracker(sender)
+	tr.observe(c)
+	tr.sendWithRetry(<-tr.requests)
+	assert.Len(t, sender.attempts, 3)
+	assert.Empty(t, sender.sent)
+	assert.Empty(t, tr.lastSeen)
+}
@@ -0,0 +1,72 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+	compressionNone = "none"
+)
+
+// compressWriter is implemented by both the gzip and zstd writers, it allows
+// the exporter to pool writers independently of the configured compression.
+type compressWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// newCompressWriterFunc returns the function used by the writer pool to
+// create new compressors for the given compression, nil if compression is
+// disabled.
+func newCompressWriterFunc(compression string) (func() interface{}, error) {
+	switch compression {
+	case compressionGzip:
+		return func() interface{} {
+			return gzip.NewWriter(nil)
+		}, nil
+	case compressionZstd:
+		return func() interface{} {
+			// The options are fixed so creating the encoder can't fail.
+			// Concurrency is limited to one since each request body is
+			// compressed by a single goroutine.
+			w, _ := zstd.NewWriter(
+				nil,
+				zstd.WithEncoderLevel(zstd.SpeedDefault),
+				zstd.WithEncoderConcurrency(1))
+			return w
+		}, nil
+	case compressionNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported \"compression\" %q", compression)
+	}
+}
+
+// contentEncoding returns the Content-Encoding header value for the given
+// compression.
+func contentEncoding(compression string) string {
+	if compression == compressionNone {
+		return ""
+	}
+	return compression
+}
@@ -18,6 +18,8 @@ import (
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry"
 )
 
 // Config defines configuration for SignalFx exporter.
@@ -27,6 +29,12 @@ type Config struct {
 	// AccessToken is the authentication token provided by SignalFx.
 	AccessToken string `mapstructure:"access_token"`
 
+	// AccessTokenPassthrough indicates whether to use the access token carried
+	// on the "com.splunk.signalfx.access_token" resource label, if present,
+	// instead of the configured one. Data is split in multiple requests when it
+	// carries different tokens. The label is not sent as a dimension.
+	AccessTokenPassthrough bool `mapstructure:"access_token_passthrough"`
+
 	// Realm is the SignalFx realm where data is going to be sent to. The
 	// default value is "us0"
 	Realm string `mapstructure:"realm"`
@@ -47,4 +55,20 @@ type Config struct {
 	// exporter, eg: "User-Agent" can be set to a custom value if specified
 	// here.
 	Headers map[string]string `mapstructure:"headers"`
+
+	// Compression is the algorithm used to compress the request body when it
+	// is large enough to benefit from it. Valid values are "gzip", "zstd" and
+	// "none". The default value is "gzip".
+	Compression string `mapstructure:"compression"`
+
+	// Authenticator is the name of the client authenticator extension, eg.:
+	// "oauth2client", adding credentials to the requests. The extension must
+	// also be enabled in the service. No authenticator is used when empty.
+	Authenticator string `mapstructure:"authenticator"`
+
+	// ResourceToTelemetrySettings defines whether the labels of the resources
+	// associated to each metric, the one of the batch and its own, should be
+	// sent as dimensions. By default only the labels of the resource of the
+	// whole batch are sent.
+	ResourceToTelemetrySettings resourcetotelemetry.Settings `mapstructure:"resource_to_telemetry_conversion"`
 }
@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -52,13 +54,19 @@ func TestLoadConfig(t *testing.T) {
 			TypeVal: typeStr,
 			NameVal: expectedName,
 		},
-		AccessToken: "testToken",
-		Realm:       "us1",
+		AccessToken:            "testToken",
+		AccessTokenPassthrough: true,
+		Realm:                  "us1",
 		Headers: map[string]string{
 			"added-entry": "added value",
 			"dot.test":    "test",
 		},
-		Timeout: 2 * time.Second,
+		Timeout:       2 * time.Second,
+		Compression:   "zstd",
+		Authenticator: "oauth2client",
+		ResourceToTelemetrySettings: resourcetotelemetry.Settings{
+			Enabled: true,
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -16,7 +16,6 @@ package signalfxexporter
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -28,13 +27,31 @@ import (
 	"sync"
 	"time"
 
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	"github.com/golang/protobuf/proto"
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/open-telemetry/opentelemetry-collector/consumer"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumererror"
 	"github.com/open-telemetry/opentelemetry-collector/exporter"
 	"github.com/open-telemetry/opentelemetry-collector/exporter/exporterhelper"
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/client"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator"
+)
+
+const (
+	// accessTokenLabel is the resource label used to pass an access token
+	// along with the data when access_token_passthrough is enabled.
+	accessTokenLabel = "com.splunk.signalfx.access_token"
+
+	accessTokenHeader = "X-Sf-Token"
 )
 
 // New returns a new SignalFx exporter.
@@ -88,6 +105,16 @@ func New(
 		return nil, err
 	}
 
+	compression := config.Compression
+	if compression == "" {
+		compression = compressionGzip
+	}
+
+	newZipper, err := newCompressWriterFunc(compression)
+	if err != nil {
+		return nil, fmt.Errorf("%q %v", config.Name(), err)
+	}
+
 	headers, err := buildHeaders(config)
 	if err != nil {
 		return nil, err
@@ -101,10 +128,10 @@ func New(
 			//  Or what others change from default values?
 			Timeout: config.Timeout,
 		},
-		logger: logger,
-		zippers: sync.Pool{New: func() interface{} {
-			return gzip.NewWriter(nil)
-		}},
+		logger:              logger,
+		encoding:            contentEncoding(compression),
+		zippers:             sync.Pool{New: newZipper},
+		resourceToTelemetry: config.ResourceToTelemetrySettings.Enabled,
 	}
 
 	exp, err := exporterhelper.NewMetricsExporter(
@@ -112,8 +139,90 @@ func New(
 		s.pushMetricsData,
 		exporterhelper.WithTracing(true),
 		exporterhelper.WithMetrics(true))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Authenticator != "" {
+		exp = &authExporter{
+			MetricsExporter: exp,
+			sender:          s,
+			authenticator:   config.Authenticator,
+		}
+	}
+
+	exp = resourcetotelemetry.WrapMetricsExporter(config.ResourceToTelemetrySettings, exp)
+
+	if config.AccessTokenPassthrough {
+		// The token must be removed before the resource labels are converted
+		// to dimensions.
+		exp = &accessTokenExporter{MetricsExporter: exp}
+		// Each request can only carry a single token, so split the data per
+		// token before handing it to the exporter.
+		exp = &batchPerTokenExporter{
+			MetricsExporter: exp,
+			batcher:         batchperresourceattr.NewBatchPerResourceMetrics(accessTokenLabel, exp),
+		}
+	}
+
+	return exp, nil
+}
+
+// batchPerTokenExporter wraps an exporter so each batch it receives only
+// carries data for a single access token.
+type batchPerTokenExporter struct {
+	exporter.MetricsExporter
+	batcher consumer.MetricsConsumer
+}
+
+func (e *batchPerTokenExporter) ConsumeMetricsData(ctx context.Context, md consumerdata.MetricsData) error {
+	return e.batcher.ConsumeMetricsData(ctx, md)
+}
+
+// accessTokenKey is the context key of the access token extracted by
+// accessTokenExporter.
+type accessTokenKey struct{}
+
+// accessTokenExporter wraps an exporter to move the access token carried by
+// each batch from its resource labels to the context.
+type accessTokenExporter struct {
+	exporter.MetricsExporter
+}
+
+func (e *accessTokenExporter) ConsumeMetricsData(ctx context.Context, md consumerdata.MetricsData) error {
+	accessToken, md := extractAccessToken(md)
+	if accessToken != "" {
+		ctx = context.WithValue(ctx, accessTokenKey{}, accessToken)
+	}
+	return e.MetricsExporter.ConsumeMetricsData(ctx, md)
+}
 
-	return exp, err
+// authExporter wraps an exporter to add the credentials of a client
+// authenticator to the requests of its sender. The authenticator is looked up
+// on Start since extensions are only available once started.
+type authExporter struct {
+	exporter.MetricsExporter
+	sender        *httpSender
+	authenticator string
+}
+
+func (e *authExporter) Start(host component.Host) error {
+	authenticator, err := auth.GetClientAuthenticator(e.authenticator)
+	if err != nil {
+		return err
+	}
+
+	base := e.sender.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, err := authenticator.RoundTripper(base)
+	if err != nil {
+		return err
+	}
+	e.sender.client.Transport = transport
+
+	return e.MetricsExporter.Start(host)
 }
 
 // httpSender sends the data to the SignalFx backend.
@@ -122,7 +231,13 @@ type httpSender struct {
 	headers map[string]string
 	client  *http.Client
 	logger  *zap.Logger
-	zippers sync.Pool
+
+	// encoding is the value of the Content-Encoding header for compressed
+	// requests, empty if compression is disabled.
+	encoding string
+	zippers  sync.Pool
+
+	resourceToTelemetry bool
 }
 
 func (s *httpSender) pushMetricsData(
@@ -130,7 +245,13 @@ func (s *httpSender) pushMetricsData(
 	md consumerdata.MetricsData,
 ) (droppedTimeSeries int, err error) {
 
-	sfxDataPoints, numDroppedTimeseries, err := metricDataToSingalFxV2(s.logger, md)
+	if s.resourceToTelemetry {
+		// The labels of the batch resource were copied to every metric, drop
+		// it so they are not duplicated as dimensions.
+		md.Resource = nil
+	}
+
+	sfxDataPoints, numDroppedTimeseries, err := signalfxtranslator.MetricDataToSignalFxV2(s.logger, md)
 	if err != nil {
 		return exporterhelper.NumTimeSeries(md), consumererror.Permanent(err)
 	}
@@ -145,12 +266,23 @@ func (s *httpSender) pushMetricsData(
 		return exporterhelper.NumTimeSeries(md), consumererror.Permanent(err)
 	}
 
+	// Authenticators like headers_setter use the metadata of the request that
+	// brought the data. Only the metadata is kept: the data may have been
+	// queued and outlive the context of that request.
+	if metadata, ok := client.FromContext(ctx); ok {
+		req = req.WithContext(client.NewContext(req.Context(), metadata))
+	}
+
 	for k, v := range s.headers {
 		req.Header.Set(k, v)
 	}
 
+	if accessToken, ok := ctx.Value(accessTokenKey{}).(string); ok {
+		req.Header.Set(accessTokenHeader, accessToken)
+	}
+
 	if compressed {
-		req.Header.Set("Content-Encoding", "gzip")
+		req.Header.Set("Content-Encoding", s.encoding)
 	}
 
 	resp, err := s.client.Do(req)
@@ -181,7 +313,7 @@ func buildHeaders(config *Config) (map[string]string, error) {
 	}
 
 	if config.AccessToken != "" {
-		headers["X-Sf-Token"] = config.AccessToken
+		headers[accessTokenHeader] = config.AccessToken
 	}
 
 	// Add any custom headers from the config. They will override the pre-defined
@@ -194,6 +326,51 @@ func buildHeaders(config *Config) (map[string]string, error) {
 	return headers, nil
 }
 
+// extractAccessToken returns the access token carried by the batch, if any,
+// and a copy of the batch without the token label, on the batch resource and
+// on the resources of the metrics, so it is not reported as a dimension. The
+// batch is expected to carry a single token, see batchPerTokenExporter.
+func extractAccessToken(md consumerdata.MetricsData) (string, consumerdata.MetricsData) {
+	if len(md.Metrics) == 0 {
+		return "", md
+	}
+
+	accessToken := batchperresourceattr.AttributeValue(
+		accessTokenLabel, md.Resource, md.Metrics[0].GetResource())
+
+	md.Resource = withoutAccessToken(md.Resource)
+	metrics := make([]*metricspb.Metric, len(md.Metrics))
+	for i, metric := range md.Metrics {
+		if resource := withoutAccessToken(metric.GetResource()); resource != metric.GetResource() {
+			m := *metric
+			m.Resource = resource
+			metric = &m
+		}
+		metrics[i] = metric
+	}
+	md.Metrics = metrics
+
+	return accessToken, md
+}
+
+// withoutAccessToken returns a copy of the resource without the token label,
+// or the resource itself if it has none.
+func withoutAccessToken(resource *resourcepb.Resource) *resourcepb.Resource {
+	if _, ok := resource.GetLabels()[accessTokenLabel]; !ok {
+		return resource
+	}
+	labels := make(map[string]string, len(resource.Labels)-1)
+	for k, v := range resource.Labels {
+		if k != accessTokenLabel {
+			labels[k] = v
+		}
+	}
+	return &resourcepb.Resource{
+		Type:   resource.Type,
+		Labels: labels,
+	}
+}
+
 func (s *httpSender) encodeBody(dps []*sfxpb.DataPoint) (bodyReader io.Reader, compressed bool, err error) {
 	msg := &sfxpb.DataPointUploadMessage{
 		Datapoints: dps,
@@ -208,9 +385,9 @@ func (s *httpSender) encodeBody(dps []*sfxpb.DataPoint) (bodyReader io.Reader, c
 // avoid attempting to compress things that fit into a single ethernet frame
 func (s *httpSender) getReader(b []byte) (io.Reader, bool, error) {
 	var err error
-	if len(b) > 1500 {
+	if s.encoding != "" && len(b) > 1500 {
 		buf := new(bytes.Buffer)
-		w := s.zippers.Get().(*gzip.Writer)
+		w := s.zippers.Get().(compressWriter)
 		defer s.zippers.Put(w)
 		w.Reset(buf)
 		_, err = w.Write(b)
@@ -17,6 +17,8 @@ package signalfxexporter
 import (
 	"compress/gzip"
 	"context"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -27,11 +29,20 @@ import (
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/klauspost/compress/zstd"
+	"github.com/open-telemetry/opentelemetry-collector/component"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
 	"github.com/open-telemetry/opentelemetry-collector/testutils/metricstestutils"
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/client"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry"
 )
 
 func TestNew(t *testing.T) {
@@ -52,6 +63,11 @@ func TestNew(t *testing.T) {
 	// This is expected to fail.
 	err = got.ConsumeMetricsData(context.Background(), consumerdata.MetricsData{})
 	assert.Error(t, err)
+
+	config.Compression = "lz4"
+	got, err = New(config, zap.NewNop())
+	assert.EqualError(t, err, `"signalfx" unsupported "compression" "lz4"`)
+	assert.Nil(t, got)
 }
 
 func TestConsumeMetricsData(t *testing.T) {
@@ -116,7 +132,8 @@ func TestConsumeMetricsData(t *testing.T) {
 				client: &http.Client{
 					Timeout: 1 * time.Second,
 				},
-				logger: zap.NewNop(),
+				logger:   zap.NewNop(),
+				encoding: compressionGzip,
 				zippers: sync.Pool{New: func() interface{} {
 					return gzip.NewWriter(nil)
 				}},
@@ -135,6 +152,404 @@ func TestConsumeMetricsData(t *testing.T) {
 	}
 }
 
+func TestConsumeMetricsDataCompression(t *testing.T) {
+	tests := []struct {
+		compression  string
+		wantEncoding string
+		newReader    func(r io.Reader) (io.Reader, error)
+	}{
+		{
+			compression:  compressionGzip,
+			wantEncoding: "gzip",
+			newReader: func(r io.Reader) (io.Reader, error) {
+				return gzip.NewReader(r)
+			},
+		},
+		{
+			compression:  compressionZstd,
+			wantEncoding: "zstd",
+			newReader: func(r io.Reader) (io.Reader, error) {
+				return zstd.NewReader(r)
+			},
+		},
+		{
+			compression:  compressionNone,
+			wantEncoding: "",
+			newReader: func(r io.Reader) (io.Reader, error) {
+				return r, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.compression, func(t *testing.T) {
+			md := generateLargeBatch(t)
+			var mu sync.Mutex
+			var numDataPoints int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantEncoding, r.Header.Get("Content-Encoding"))
+				// The handler runs in the server goroutine, where require
+				// can't stop the test.
+				reader, err := tt.newReader(r.Body)
+				if !assert.NoError(t, err) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				body, err := ioutil.ReadAll(reader)
+				if !assert.NoError(t, err) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				msg := &sfxpb.DataPointUploadMessage{}
+				if !assert.NoError(t, proto.Unmarshal(body, msg)) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				mu.Lock()
+				numDataPoints = len(msg.Datapoints)
+				mu.Unlock()
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			config := &Config{
+				URL:         server.URL,
+				Compression: tt.compression,
+			}
+			exp, err := New(config, zap.NewNop())
+			require.NoError(t, err)
+
+			require.NoError(t, exp.ConsumeMetricsData(context.Background(), *md))
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, len(md.Metrics), numDataPoints)
+		})
+	}
+}
+
+func TestConsumeMetricsDataWithAccessTokenPassthrough(t *testing.T) {
+	var mu sync.Mutex
+	var receivedTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		receivedTokens = append(receivedTokens, r.Header.Get("X-Sf-Token"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		AccessToken:            "ClientAccessToken",
+		AccessTokenPassthrough: true,
+		URL:                    server.URL,
+	}
+	exp, err := New(config, zap.NewNop())
+	require.NoError(t, err)
+
+	newMetric := func(name string, res *resourcepb.Resource) *metricspb.Metric {
+		m := metricstestutils.Gauge(
+			name,
+			[]string{"k0"},
+			metricstestutils.Timeseries(
+				time.Now(),
+				[]string{"v0"},
+				metricstestutils.Double(time.Now(), 123)))
+		m.Resource = res
+		return m
+	}
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Labels: map[string]string{accessTokenLabel: "BatchAccessToken"},
+		},
+		Metrics: []*metricspb.Metric{
+			newMetric("m0", nil),
+			newMetric("m1", &resourcepb.Resource{
+				Labels: map[string]string{accessTokenLabel: "MetricAccessToken"},
+			}),
+			newMetric("m2", &resourcepb.Resource{}),
+		},
+	}
+
+	require.NoError(t, exp.ConsumeMetricsData(context.Background(), md))
+	assert.ElementsMatch(t,
+		[]string{"BatchAccessToken", "MetricAccessToken", "ClientAccessToken"},
+		receivedTokens)
+}
+
+// receivedDataPoint is what a dimensionsServer got for a data point.
+type receivedDataPoint struct {
+	accessToken string
+	dimensions  map[string]string
+}
+
+// newDimensionsServer returns a server decoding uncompressed requests and
+// recording the dimensions of their data points per metric.
+func newDimensionsServer(t *testing.T) (*httptest.Server, func() map[string]receivedDataPoint) {
+	var mu sync.Mutex
+	received := make(map[string]receivedDataPoint)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		msg := &sfxpb.DataPointUploadMessage{}
+		if !assert.NoError(t, proto.Unmarshal(body, msg)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		for _, dp := range msg.Datapoints {
+			dimensions := make(map[string]string, len(dp.Dimensions))
+			for _, d := range dp.Dimensions {
+				dimensions[d.Key] = d.Value
+			}
+			received[dp.Metric] = receivedDataPoint{
+				accessToken: r.Header.Get("X-Sf-Token"),
+				dimensions:  dimensions,
+			}
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return server, func() map[string]receivedDataPoint {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+}
+
+func newGaugeWithResource(name string, res *resourcepb.Resource) *metricspb.Metric {
+	m := metricstestutils.Gauge(
+		name,
+		[]string{"k0"},
+		metricstestutils.Timeseries(
+			time.Now(),
+			[]string{"v0"},
+			metricstestutils.Double(time.Now(), 123)))
+	m.Resource = res
+	return m
+}
+
+func TestConsumeMetricsDataWithResourceToTelemetry(t *testing.T) {
+	server, received := newDimensionsServer(t)
+	defer server.Close()
+
+	config := &Config{
+		URL:         server.URL,
+		Compression: compressionNone,
+		ResourceToTelemetrySettings: resourcetotelemetry.Settings{
+			Enabled: true,
+		},
+	}
+	exp, err := New(config, zap.NewNop())
+	require.NoError(t, err)
+
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Labels: map[string]string{"host.name": "h0", "k0": "resource"},
+		},
+		Metrics: []*metricspb.Metric{
+			newGaugeWithResource("m0", nil),
+			newGaugeWithResource("m1", &resourcepb.Resource{
+				Labels: map[string]string{"container.id": "c0"},
+			}),
+		},
+	}
+
+	require.NoError(t, exp.ConsumeMetricsData(context.Background(), md))
+	got := received()
+	// The labels of the batch resource are kept for the metrics with their
+	// own resource.
+	assert.Equal(t,
+		map[string]string{"host.name": "h0", "k0": "v0"},
+		got["m0"].dimensions)
+	assert.Equal(t,
+		map[string]string{"host.name": "h0", "container.id": "c0", "k0": "v0"},
+		got["m1"].dimensions)
+}
+
+func TestConsumeMetricsDataWithAccessTokenPassthroughAndResourceToTelemetry(t *testing.T) {
+	server, received := newDimensionsServer(t)
+	defer server.Close()
+
+	config := &Config{
+		AccessToken:            "ClientAccessToken",
+		AccessTokenPassthrough: true,
+		URL:                    server.URL,
+		Compression:            compressionNone,
+		ResourceToTelemetrySettings: resourcetotelemetry.Settings{
+			Enabled: true,
+		},
+	}
+	exp, err := New(config, zap.NewNop())
+	require.NoError(t, err)
+
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Labels: map[string]string{
+				accessTokenLabel: "BatchAccessToken",
+				"host.name":      "h0",
+			},
+		},
+		Metrics: []*metricspb.Metric{
+			newGaugeWithResource("m0", nil),
+			newGaugeWithResource("m1", &resourcepb.Resource{
+				Labels: map[string]string{
+					accessTokenLabel: "MetricAccessToken",
+					"container.id":   "c0",
+				},
+			}),
+		},
+	}
+
+	require.NoError(t, exp.ConsumeMetricsData(context.Background(), md))
+	got := received()
+	require.Len(t, got, 2)
+	assert.Equal(t, "BatchAccessToken", got["m0"].accessToken)
+	assert.Equal(t, "MetricAccessToken", got["m1"].accessToken)
+	for metric, dp := range got {
+		assert.NotContains(t, dp.dimensions, accessTokenLabel, metric)
+	}
+	assert.Equal(t, "c0", got["m1"].dimensions["container.id"])
+	// The original batch must not be modified.
+	assert.Equal(t, "MetricAccessToken", md.Metrics[1].Resource.Labels[accessTokenLabel])
+}
+
+type mockClientAuthenticator struct {
+	token string
+}
+
+func (m *mockClientAuthenticator) Start(host component.Host) error {
+	return nil
+}
+
+func (m *mockClientAuthenticator) Shutdown() error {
+	return nil
+}
+
+func (m *mockClientAuthenticator) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		req := *r
+		req.Header = make(http.Header, len(r.Header)+1)
+		for k, v := range r.Header {
+			req.Header[k] = v
+		}
+		req.Header.Set("Authorization", "Bearer "+m.token)
+		return base.RoundTrip(&req)
+	}), nil
+}
+
+func (m *mockClientAuthenticator) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return nil, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestConsumeMetricsDataWithAuthenticator(t *testing.T) {
+	var mu sync.Mutex
+	var receivedAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		receivedAuthorization = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ext := auth.NewClientAuthenticatorExtension("mockauth", &mockClientAuthenticator{token: "t0k3n"})
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	defer ext.Shutdown()
+
+	config := &Config{
+		URL:           server.URL,
+		Authenticator: "mockauth",
+	}
+	exp, err := New(config, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(component.NewMockHost()))
+	defer exp.Shutdown()
+
+	require.NoError(t, exp.ConsumeMetricsData(context.Background(), *generateLargeBatch(t)))
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "Bearer t0k3n", receivedAuthorization)
+}
+
+func TestConsumeMetricsDataKeepsClientMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	var metadata client.Metadata
+	sender := &httpSender{
+		url: server.URL,
+		client: &http.Client{
+			Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				metadata, _ = client.FromContext(r.Context())
+				return http.DefaultTransport.RoundTrip(r)
+			}),
+		},
+		logger: zap.NewNop(),
+	}
+
+	// The metadata must be kept even if the context of the incoming request
+	// is already done.
+	ctx, cancel := context.WithCancel(client.NewContext(
+		context.Background(), client.Metadata{"X-Tenant": {"acme"}}))
+	cancel()
+
+	_, err := sender.pushMetricsData(ctx, *generateLargeBatch(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme"}, metadata.Get("X-Tenant"))
+}
+
+func TestStartWithUnknownAuthenticator(t *testing.T) {
+	config := &Config{
+		URL:           "http://localhost",
+		Authenticator: "unknown",
+	}
+	exp, err := New(config, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, exp.Start(component.NewMockHost()))
+}
+
+func TestExtractAccessToken(t *testing.T) {
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Type: "test",
+			Labels: map[string]string{
+				accessTokenLabel: "BatchAccessToken",
+				"k0":             "v0",
+			},
+		},
+		Metrics: []*metricspb.Metric{
+			{},
+			{
+				Resource: &resourcepb.Resource{
+					Labels: map[string]string{
+						accessTokenLabel: "BatchAccessToken",
+						"k1":             "v1",
+					},
+				},
+			},
+		},
+	}
+
+	accessToken, got := extractAccessToken(md)
+	assert.Equal(t, "BatchAccessToken", accessToken)
+	assert.Equal(t, &resourcepb.Resource{Type: "test", Labels: map[string]string{"k0": "v0"}}, got.Resource)
+	assert.Nil(t, got.Metrics[0].Resource)
+	assert.Equal(t, &resourcepb.Resource{Labels: map[string]string{"k1": "v1"}}, got.Metrics[1].Resource)
+	// The original batch must not be modified.
+	assert.Equal(t, "BatchAccessToken", md.Resource.Labels[accessTokenLabel])
+	assert.Equal(t, "BatchAccessToken", md.Metrics[1].Resource.Labels[accessTokenLabel])
+}
+
 func generateLargeBatch(t *testing.T) *consumerdata.MetricsData {
 	md := &consumerdata.MetricsData{
 		Node: &commonpb.Node{
@@ -47,8 +47,9 @@ func (f *Factory) CreateDefaultConfig() configmodels.Exporter {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
-		Realm:   defaultSFxRealm,
-		Timeout: defaultHTTPTimeout,
+		Realm:       defaultSFxRealm,
+		Timeout:     defaultHTTPTimeout,
+		Compression: compressionGzip,
 	}
 }
 
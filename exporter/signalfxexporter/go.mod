@@ -5,8 +5,24 @@ go 1.12
 require (
 	github.com/census-instrumentation/opencensus-proto v0.2.1
 	github.com/golang/protobuf v1.3.2
+	github.com/klauspost/compress v1.10.5
 	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/client v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator v0.0.0
 	github.com/signalfx/com_signalfx_metrics_protobuf v0.0.0-20190530013331-054be550cb49
 	github.com/stretchr/testify v1.4.0
 	go.uber.org/zap v1.12.0
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr => ../../internal/batchperresourceattr
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry => ../../internal/resourcetotelemetry
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator => ../../internal/signalfxtranslator
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../../extension/auth
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/client => ../../internal/client
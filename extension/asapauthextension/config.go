@@ -0,0 +1,47 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asapauthextension
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the ASAP auth extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// KeyID is the identifier of the key pair, the receiver uses it to look
+	// up the public key. Has no default value.
+	KeyID string `mapstructure:"key_id"`
+
+	// Issuer is the identifier of the sender, set as the "iss" claim. Has no
+	// default value.
+	Issuer string `mapstructure:"issuer"`
+
+	// Audience lists the identifiers of the intended receivers, set as the
+	// "aud" claim. Has no default value.
+	Audience []string `mapstructure:"audience"`
+
+	// PrivateKey is the RSA key used to sign the tokens, either PEM encoded
+	// or as an ASAP data URI ("data:application/pkcs8;kid=...;base64,...").
+	// Has no default value.
+	PrivateKey string `mapstructure:"private_key"`
+
+	// TTL is the lifetime of the tokens. A token is reused until half of its
+	// lifetime has elapsed. The default value is 60 seconds.
+	TTL time.Duration `mapstructure:"ttl"`
+}
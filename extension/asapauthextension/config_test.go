@@ -0,0 +1,66 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asapauthextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrivateKey = "data:application/pkcs8;kid=somekeyid;base64,MIIBUwIBADANBgkqhkiG9w0BAQEFAASCAT0wggE5AgEAAkEA"
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := config.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := &Factory{}
+	factories.Extensions[typeStr] = factory
+	cfg, err := config.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	ext0 := cfg.Extensions["asapclient"]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.KeyID = "somekeyid"
+	defaultCfg.Issuer = "someissuer"
+	defaultCfg.Audience = []string{"someservice"}
+	defaultCfg.PrivateKey = testPrivateKey
+	assert.Equal(t, defaultCfg, ext0)
+
+	ext1 := cfg.Extensions["asapclient/1"]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				TypeVal: typeStr,
+				NameVal: "asapclient/1",
+			},
+			KeyID:      "somekeyid",
+			Issuer:     "someissuer",
+			Audience:   []string{"someservice", "someotherservice"},
+			PrivateKey: testPrivateKey,
+			TTL:        30 * time.Second,
+		},
+		ext1)
+
+	assert.Equal(t, 1, len(cfg.Service.Extensions))
+	assert.Equal(t, "asapclient/1", cfg.Service.Extensions[0])
+}
@@ -0,0 +1,198 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asapauthextension
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+// asapAuth adds ASAP tokens to outgoing requests. Tokens are cached and a
+// new one is minted once half of the lifetime of the current one elapsed.
+type asapAuth struct {
+	cfg *Config
+	key *rsa.PrivateKey
+	now func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+var _ auth.ClientAuthenticator = (*asapAuth)(nil)
+
+func newASAPAuth(cfg *Config) (*asapAuth, error) {
+	if cfg.KeyID == "" {
+		return nil, errNoKeyID
+	}
+	if cfg.Issuer == "" {
+		return nil, errNoIssuer
+	}
+	if len(cfg.Audience) == 0 {
+		return nil, errNoAudience
+	}
+	if cfg.PrivateKey == "" {
+		return nil, errNoPrivateKey
+	}
+
+	key, err := parsePrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse \"private_key\": %v", err)
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+
+	return &asapAuth{
+		cfg: cfg,
+		key: key,
+		now: time.Now,
+	}, nil
+}
+
+// Start is a no-op.
+func (a *asapAuth) Start(host component.Host) error {
+	return nil
+}
+
+// Shutdown is a no-op.
+func (a *asapAuth) Shutdown() error {
+	return nil
+}
+
+// RoundTripper returns a round tripper setting the "Authorization" header of
+// the requests to a valid token.
+func (a *asapAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &asapRoundTripper{
+		base: base,
+		auth: a,
+	}, nil
+}
+
+// PerRPCCredentials returns gRPC credentials carrying a valid token.
+func (a *asapAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCAuth{auth: a}, nil
+}
+
+// authorizationHeader returns the value of the "Authorization" header,
+// minting a new token if needed.
+func (a *asapAuth) authorizationHeader() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if a.token == "" || !now.Before(a.refreshAt) {
+		token, err := a.mint(now)
+		if err != nil {
+			return "", err
+		}
+		a.token = token
+		a.refreshAt = now.Add(a.cfg.TTL / 2)
+	}
+	return "Bearer " + a.token, nil
+}
+
+// mint creates a token signed with RS256, as required by the ASAP
+// specification.
+func (a *asapAuth) mint(now time.Time) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": a.cfg.KeyID,
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": a.cfg.Issuer,
+		"sub": a.cfg.Issuer,
+		"aud": a.cfg.Audience,
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(a.cfg.TTL).Unix(),
+		"jti": hex.EncodeToString(jti),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the ASAP token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+type asapRoundTripper struct {
+	base http.RoundTripper
+	auth *asapAuth
+}
+
+func (r *asapRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	authorization, err := r.auth.authorizationHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	// Round trippers must not modify the original request.
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		newReq.Header[k] = v
+	}
+	newReq.Header.Set("Authorization", authorization)
+	return r.base.RoundTrip(newReq)
+}
+
+type perRPCAuth struct {
+	auth *asapAuth
+}
+
+func (p *perRPCAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	authorization, err := p.auth.authorizationHeader()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": authorization}, nil
+}
+
+// RequireTransportSecurity always returns true since the token is sent in
+// clear text.
+func (p *perRPCAuth) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,172 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asapauthextension
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func pkcs8DataURI(t *testing.T, key *rsa.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return "data:application/pkcs8;kid=somekeyid;base64," + base64.StdEncoding.EncodeToString(der)
+}
+
+// verifyToken checks the signature of the token and returns its header and
+// claims.
+func verifyToken(t *testing.T, key *rsa.PrivateKey, token string) (map[string]interface{}, map[string]interface{}) {
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	decode := func(part string) map[string]interface{} {
+		raw, err := base64.RawURLEncoding.DecodeString(part)
+		require.NoError(t, err)
+		m := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(raw, &m))
+		return m
+	}
+	return decode(parts[0]), decode(parts[1])
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := generateKey(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{
+			name: "data_uri",
+			key:  pkcs8DataURI(t, key),
+		},
+		{
+			name: "pkcs1_pem",
+			key:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		},
+		{
+			name: "pkcs8_pem",
+			key:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parsePrivateKey(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, key.D, parsed.D)
+		})
+	}
+
+	_, err = parsePrivateKey("data:application/pkcs8;kid=somekeyid,notbase64")
+	assert.Error(t, err)
+	_, err = parsePrivateKey("not a key")
+	assert.Error(t, err)
+}
+
+func TestRoundTripper(t *testing.T) {
+	key := generateKey(t)
+	a, err := newASAPAuth(&Config{
+		KeyID:      "somekeyid",
+		Issuer:     "someissuer",
+		Audience:   []string{"someservice"},
+		PrivateKey: pkcs8DataURI(t, key),
+		TTL:        time.Minute,
+	})
+	require.NoError(t, err)
+	now := time.Unix(1580000000, 0)
+	a.now = func() time.Time { return now }
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	rt, err := a.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	client := &http.Client{Transport: rt}
+	send := func() string {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.True(t, strings.HasPrefix(authorization, "Bearer "))
+		return strings.TrimPrefix(authorization, "Bearer ")
+	}
+
+	token := send()
+	header, claims := verifyToken(t, key, token)
+	assert.Equal(t, "RS256", header["alg"])
+	assert.Equal(t, "somekeyid", header["kid"])
+	assert.Equal(t, "someissuer", claims["iss"])
+	assert.Equal(t, []interface{}{"someservice"}, claims["aud"])
+	assert.Equal(t, float64(now.Unix()), claims["iat"])
+	assert.Equal(t, float64(now.Add(time.Minute).Unix()), claims["exp"])
+	assert.NotEmpty(t, claims["jti"])
+
+	// The token is reused until half of its lifetime elapsed.
+	now = now.Add(29 * time.Second)
+	assert.Equal(t, token, send())
+	now = now.Add(time.Second)
+	assert.NotEqual(t, token, send())
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	key := generateKey(t)
+	a, err := newASAPAuth(&Config{
+		KeyID:      "somekeyid",
+		Issuer:     "someissuer",
+		Audience:   []string{"someservice"},
+		PrivateKey: pkcs8DataURI(t, key),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultTTL, a.cfg.TTL)
+
+	creds, err := a.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(md["authorization"], "Bearer "))
+	verifyToken(t, key, strings.TrimPrefix(md["authorization"], "Bearer "))
+}
@@ -0,0 +1,75 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asapauthextension
+
+import (
+	"errors"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/open-telemetry/opentelemetry-collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "asapclient"
+
+	defaultTTL = 60 * time.Second
+)
+
+var (
+	errNoKeyID      = errors.New("\"key_id\" config cannot be empty")
+	errNoIssuer     = errors.New("\"issuer\" config cannot be empty")
+	errNoAudience   = errors.New("\"audience\" config cannot be empty")
+	errNoPrivateKey = errors.New("\"private_key\" config cannot be empty")
+)
+
+// Factory is the factory for the ASAP auth extension.
+type Factory struct {
+}
+
+var _ extension.Factory = (*Factory)(nil)
+
+// Type gets the type of the config created by this factory.
+func (f *Factory) Type() string {
+	return typeStr
+}
+
+// CreateDefaultConfig creates the default configuration for the extension.
+func (f *Factory) CreateDefaultConfig() configmodels.Extension {
+	return &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		TTL: defaultTTL,
+	}
+}
+
+// CreateExtension creates the extension based on this config.
+func (f *Factory) CreateExtension(
+	logger *zap.Logger,
+	cfg configmodels.Extension,
+) (extension.ServiceExtension, error) {
+	ext, err := newASAPAuth(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewClientAuthenticatorExtension(cfg.Name(), ext), nil
+}
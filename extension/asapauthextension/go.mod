@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/asapauthextension
+
+go 1.12
+
+require (
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth v0.0.0
+	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.13.0
+	google.golang.org/grpc v1.25.1
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../auth
@@ -0,0 +1,66 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asapauthextension
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+)
+
+const dataURIPrefix = "data:application/pkcs8;"
+
+var errUnsupportedKey = errors.New("private key must be an RSA key")
+
+// parsePrivateKey parses an RSA private key given either in PEM format
+// (PKCS #1 or PKCS #8) or as an ASAP data URI holding a PKCS #8 key.
+func parsePrivateKey(key string) (*rsa.PrivateKey, error) {
+	key = strings.TrimSpace(key)
+
+	var der []byte
+	if strings.HasPrefix(key, dataURIPrefix) {
+		idx := strings.Index(key, ";base64,")
+		if idx < 0 {
+			return nil, errors.New("private key data URI must be base64 encoded")
+		}
+		decoded, err := base64.StdEncoding.DecodeString(key[idx+len(";base64,"):])
+		if err != nil {
+			return nil, err
+		}
+		der = decoded
+	} else {
+		block, _ := pem.Decode([]byte(key))
+		if block == nil {
+			return nil, errors.New("private key is neither PEM encoded nor a data URI")
+		}
+		if block.Type == "RSA PRIVATE KEY" {
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		}
+		der = block.Bytes
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errUnsupportedKey
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth defines the interfaces implemented by the authentication
+// extensions. Client authenticators add credentials to the requests sent by
+// exporters, server authenticators validate the credentials of the requests
+// received by receivers.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/open-telemetry/opentelemetry-collector/extension"
+	"google.golang.org/grpc/credentials"
+)
+
+// ErrNotAuthenticated is returned by server authenticators when a request
+// doesn't carry valid credentials.
+var ErrNotAuthenticated = errors.New("request is not authenticated")
+
+// ClientAuthenticator is the interface that extensions adding credentials to
+// outgoing requests must implement.
+type ClientAuthenticator interface {
+	extension.ServiceExtension
+
+	// RoundTripper returns a round tripper that adds the credentials to the
+	// requests before handing them to base.
+	RoundTripper(base http.RoundTripper) (http.RoundTripper, error)
+
+	// PerRPCCredentials returns the credentials to be attached to outgoing
+	// gRPC calls.
+	PerRPCCredentials() (credentials.PerRPCCredentials, error)
+}
+
+// ServerAuthenticator is the interface that extensions validating the
+// credentials of incoming requests must implement.
+type ServerAuthenticator interface {
+	extension.ServiceExtension
+
+	// Authenticate checks the headers, or gRPC metadata, of an incoming
+	// request. On success it returns a context that may carry information
+	// about the authenticated principal, otherwise it returns an error,
+	// typically wrapping ErrNotAuthenticated.
+	Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error)
+}
+
+// NewHTTPHandler returns a handler that authenticates the requests with the
+// given authenticator before passing them to next. Requests failing the
+// authentication are rejected with a 401 status code.
+func NewHTTPHandler(authenticator ServerAuthenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := authenticator.Authenticate(r.Context(), r.Header)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
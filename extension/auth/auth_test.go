@@ -0,0 +1,67 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+)
+
+type principalKey struct{}
+
+type mockServerAuthenticator struct {
+	token string
+}
+
+func (m *mockServerAuthenticator) Start(host component.Host) error {
+	return nil
+}
+
+func (m *mockServerAuthenticator) Shutdown() error {
+	return nil
+}
+
+func (m *mockServerAuthenticator) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	values := headers["Authorization"]
+	if len(values) != 1 || values[0] != m.token {
+		return ctx, ErrNotAuthenticated
+	}
+	return context.WithValue(ctx, principalKey{}, "user"), nil
+}
+
+func TestNewHTTPHandler(t *testing.T) {
+	var principal interface{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = r.Context().Value(principalKey{})
+	})
+	handler := NewHTTPHandler(&mockServerAuthenticator{token: "secret"}, next)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Nil(t, principal)
+
+	req.Header.Set("Authorization", "secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user", principal)
+}
@@ -0,0 +1,9 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth
+
+go 1.12
+
+require (
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/stretchr/testify v1.4.0
+	google.golang.org/grpc v1.25.1
+)
@@ -0,0 +1,145 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+)
+
+// The host given to the components can't look up extensions, so the
+// authenticators register themselves, under the name of their configuration,
+// when they are started. Extensions are started before the pipelines, so the
+// exporters and receivers can look them up from their own Start.
+var (
+	registryMu           sync.RWMutex
+	clientAuthenticators = make(map[string]ClientAuthenticator)
+	serverAuthenticators = make(map[string]ServerAuthenticator)
+)
+
+// NewClientAuthenticatorExtension returns the extension to be created by the
+// factories of client authenticators. Once started, the authenticator can be
+// found with GetClientAuthenticator, using the name of its configuration,
+// until the extension is shut down.
+func NewClientAuthenticatorExtension(name string, authenticator ClientAuthenticator) ClientAuthenticator {
+	return &registeredClientAuthenticator{
+		ClientAuthenticator: authenticator,
+		name:                name,
+	}
+}
+
+// NewServerAuthenticatorExtension returns the extension to be created by the
+// factories of server authenticators. Once started, the authenticator can be
+// found with GetServerAuthenticator, using the name of its configuration,
+// until the extension is shut down.
+func NewServerAuthenticatorExtension(name string, authenticator ServerAuthenticator) ServerAuthenticator {
+	return &registeredServerAuthenticator{
+		ServerAuthenticator: authenticator,
+		name:                name,
+	}
+}
+
+// GetClientAuthenticator returns the started client authenticator with the
+// given name.
+func GetClientAuthenticator(name string) (ClientAuthenticator, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	authenticator, ok := clientAuthenticators[name]
+	if !ok {
+		return nil, notFoundError("client", name)
+	}
+	return authenticator, nil
+}
+
+// GetServerAuthenticator returns the started server authenticator with the
+// given name.
+func GetServerAuthenticator(name string) (ServerAuthenticator, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	authenticator, ok := serverAuthenticators[name]
+	if !ok {
+		return nil, notFoundError("server", name)
+	}
+	return authenticator, nil
+}
+
+func notFoundError(kind, name string) error {
+	return fmt.Errorf(
+		"%s authenticator %q not found, it must be configured and enabled as an extension",
+		kind,
+		name)
+}
+
+type registeredClientAuthenticator struct {
+	ClientAuthenticator
+	name string
+}
+
+func (r *registeredClientAuthenticator) Start(host component.Host) error {
+	if err := r.ClientAuthenticator.Start(host); err != nil {
+		return err
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := clientAuthenticators[r.name]; ok {
+		return fmt.Errorf("client authenticator %q is already registered", r.name)
+	}
+	clientAuthenticators[r.name] = r.ClientAuthenticator
+	return nil
+}
+
+func (r *registeredClientAuthenticator) Shutdown() error {
+	registryMu.Lock()
+	if clientAuthenticators[r.name] == r.ClientAuthenticator {
+		delete(clientAuthenticators, r.name)
+	}
+	registryMu.Unlock()
+
+	return r.ClientAuthenticator.Shutdown()
+}
+
+type registeredServerAuthenticator struct {
+	ServerAuthenticator
+	name string
+}
+
+func (r *registeredServerAuthenticator) Start(host component.Host) error {
+	if err := r.ServerAuthenticator.Start(host); err != nil {
+		return err
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := serverAuthenticators[r.name]; ok {
+		return fmt.Errorf("server authenticator %q is already registered", r.name)
+	}
+	serverAuthenticators[r.name] = r.ServerAuthenticator
+	return nil
+}
+
+func (r *registeredServerAuthenticator) Shutdown() error {
+	registryMu.Lock()
+	if serverAuthenticators[r.name] == r.ServerAuthenticator {
+		delete(serverAuthenticators, r.name)
+	}
+	registryMu.Unlock()
+
+	return r.ServerAuthenticator.Shutdown()
+}
@@ -0,0 +1,86 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+)
+
+type mockClientAuthenticator struct{}
+
+func (m *mockClientAuthenticator) Start(host component.Host) error {
+	return nil
+}
+
+func (m *mockClientAuthenticator) Shutdown() error {
+	return nil
+}
+
+func (m *mockClientAuthenticator) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return base, nil
+}
+
+func (m *mockClientAuthenticator) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return nil, nil
+}
+
+func TestClientAuthenticatorRegistry(t *testing.T) {
+	authenticator := &mockClientAuthenticator{}
+	ext := NewClientAuthenticatorExtension("mock/client", authenticator)
+
+	_, err := GetClientAuthenticator("mock/client")
+	assert.Error(t, err, "not registered before start")
+
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	got, err := GetClientAuthenticator("mock/client")
+	require.NoError(t, err)
+	assert.Equal(t, authenticator, got)
+
+	_, err = GetServerAuthenticator("mock/client")
+	assert.Error(t, err, "client authenticators aren't server authenticators")
+
+	duplicate := NewClientAuthenticatorExtension("mock/client", &mockClientAuthenticator{})
+	assert.Error(t, duplicate.Start(component.NewMockHost()))
+
+	require.NoError(t, ext.Shutdown())
+	_, err = GetClientAuthenticator("mock/client")
+	assert.Error(t, err, "unregistered after shutdown")
+}
+
+func TestServerAuthenticatorRegistry(t *testing.T) {
+	authenticator := &mockServerAuthenticator{token: "secret"}
+	ext := NewServerAuthenticatorExtension("mock/server", authenticator)
+
+	_, err := GetServerAuthenticator("mock/server")
+	assert.Error(t, err, "not registered before start")
+
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	got, err := GetServerAuthenticator("mock/server")
+	require.NoError(t, err)
+	assert.Equal(t, authenticator, got)
+
+	duplicate := NewServerAuthenticatorExtension("mock/server", &mockServerAuthenticator{})
+	assert.Error(t, duplicate.Start(component.NewMockHost()))
+
+	require.NoError(t, ext.Shutdown())
+	_, err = GetServerAuthenticator("mock/server")
+	assert.Error(t, err, "unregistered after shutdown")
+}
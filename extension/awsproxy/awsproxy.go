@@ -0,0 +1,207 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsproxy
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/open-telemetry/opentelemetry-collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+)
+
+// xrayService is the signing name of the X-Ray API.
+const xrayService = "xray"
+
+// awsProxy listens for requests of the X-Ray SDKs and forwards them, signed,
+// to the X-Ray API.
+type awsProxy struct {
+	cfg    *Config
+	logger *zap.Logger
+	conn   awsutil.ConnAttr
+	server *http.Server
+}
+
+var _ extension.ServiceExtension = (*awsProxy)(nil)
+
+func newAWSProxy(cfg *Config, logger *zap.Logger) (*awsProxy, error) {
+	if cfg.ProxyAddress != "" {
+		if _, err := url.Parse(cfg.ProxyAddress); err != nil {
+			return nil, fmt.Errorf("%q invalid \"proxy_address\": %v", cfg.Name(), err)
+		}
+	}
+	if cfg.AWSEndpoint != "" {
+		if _, err := parseUpstream(cfg.AWSEndpoint); err != nil {
+			return nil, fmt.Errorf("%q invalid \"aws_endpoint\": %v", cfg.Name(), err)
+		}
+	}
+
+	return &awsProxy{
+		cfg:    cfg,
+		logger: logger,
+		conn:   &awsutil.Conn{},
+	}, nil
+}
+
+// Start resolves the region and credentials and starts listening for
+// requests to forward.
+func (p *awsProxy) Start(host component.Host) error {
+	handler, err := p.newHandler()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", p.cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	p.server = &http.Server{
+		Handler: handler,
+	}
+	go func() {
+		if err := p.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown stops the server, requests being forwarded are interrupted.
+func (p *awsProxy) Shutdown() error {
+	if p.server == nil {
+		return nil
+	}
+	return p.server.Close()
+}
+
+func (p *awsProxy) newHandler() (http.Handler, error) {
+	sessionCfg := awsutil.CreateDefaultSessionConfig()
+	sessionCfg.ProxyAddress = p.cfg.ProxyAddress
+	sessionCfg.Region = p.cfg.Region
+	sessionCfg.RoleARN = p.cfg.RoleARN
+	sessionCfg.CertificateFilePath = p.cfg.CertificateFilePath
+	sessionCfg.NoVerifySSL = p.cfg.NoVerifySSL
+
+	awsCfg, sess, err := awsutil.GetAWSConfigSession(p.logger, p.conn, &sessionCfg)
+	if err != nil {
+		return nil, err
+	}
+	region := aws.StringValue(awsCfg.Region)
+
+	transport, err := awsutil.ProxyServerTransport(p.logger, &sessionCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream := p.cfg.AWSEndpoint
+	if upstream == "" {
+		resolved, err := endpoints.DefaultResolver().EndpointFor(xrayService, region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the X-Ray endpoint: %v", err)
+		}
+		upstream = resolved.URL
+	}
+	upstreamURL, err := parseUpstream(upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.Info("Forwarding requests to AWS",
+		zap.String("endpoint", upstreamURL.String()),
+		zap.String("region", region))
+
+	proxy := httputil.NewSingleHostReverseProxy(upstreamURL)
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		// Use the host of the upstream, the one from the client refers to the
+		// extension itself, and it is part of the signature.
+		req.Host = upstreamURL.Host
+	}
+	proxy.Transport = &signingTransport{
+		base:   transport,
+		signer: v4.NewSigner(sess.Config.Credentials),
+		region: region,
+	}
+	proxy.ErrorHandler = p.handleError
+	return proxy, nil
+}
+
+func (p *awsProxy) handleError(writer http.ResponseWriter, request *http.Request, err error) {
+	p.logger.Debug(
+		"Failed to forward request",
+		zap.String("method", request.Method),
+		zap.String("path", request.URL.Path),
+		zap.Error(err))
+	writer.WriteHeader(http.StatusBadGateway)
+}
+
+func parseUpstream(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("endpoint must include the scheme and host: %q", endpoint)
+	}
+	return u, nil
+}
+
+// signingTransport signs the requests with AWS Signature Version 4 before
+// sending them.
+type signingTransport struct {
+	base   http.RoundTripper
+	signer *v4.Signer
+	region string
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The payload is part of the signature, it needs to be read upfront.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Headers added by the SDKs or hops in between could differ from the
+	// ones expected by AWS and break the signature.
+	req.Header.Del("Authorization")
+	req.Header.Del("X-Amz-Date")
+	req.Header.Del("X-Amz-Security-Token")
+	req.Header.Del("X-Forwarded-For")
+
+	if _, err := t.signer.Sign(req, bytes.NewReader(body), xrayService, t.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign the request: %v", err)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,168 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsproxy
+
+import (
+	"bytes"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/open-telemetry/opentelemetry-collector/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setEnv(t *testing.T, key, value string) func() {
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	return func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestForwardsSignedRequests(t *testing.T) {
+	defer setEnv(t, "AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")()
+	defer setEnv(t, "AWS_SECRET_ACCESS_KEY", "secret")()
+
+	var received *http.Request
+	var body []byte
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"SamplingRuleRecords":[]}`))
+	}))
+	defer upstream.Close()
+	upstreamURL, err := url.Parse(upstream.URL)
+	require.NoError(t, err)
+
+	endpoint := testutils.GetAvailableLocalAddress(t)
+	p, err := newAWSProxy(&Config{
+		Endpoint:    endpoint,
+		Region:      "us-west-2",
+		AWSEndpoint: upstream.URL,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(component.NewMockHost()))
+	defer p.Shutdown()
+
+	resp, err := http.Post("http://"+endpoint+"/GetSamplingRules", "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"SamplingRuleRecords":[]}`, string(respBody))
+
+	require.NotNil(t, received)
+	assert.Equal(t, "/GetSamplingRules", received.URL.Path)
+	assert.Equal(t, upstreamURL.Host, received.Host)
+	assert.Equal(t, []byte("{}"), body)
+	authorization := received.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), authorization)
+	assert.Contains(t, authorization, "/us-west-2/xray/aws4_request")
+}
+
+func TestForwardsToTLSUpstream(t *testing.T) {
+	defer setEnv(t, "AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")()
+	defer setEnv(t, "AWS_SECRET_ACCESS_KEY", "secret")()
+
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"SamplingRuleRecords":[]}`))
+	}))
+	defer upstream.Close()
+
+	dir, err := ioutil.TempDir("", "awsproxy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: upstream.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caFile, caPEM, 0600))
+
+	tests := []struct {
+		name                string
+		certificateFilePath string
+		noVerifySSL         bool
+		wantStatusCode      int
+	}{
+		{
+			name:                "certificate_file_path",
+			certificateFilePath: caFile,
+			wantStatusCode:      http.StatusOK,
+		},
+		{
+			name:           "no_verify_ssl",
+			noVerifySSL:    true,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "unknown_authority",
+			wantStatusCode: http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := testutils.GetAvailableLocalAddress(t)
+			p, err := newAWSProxy(&Config{
+				Endpoint:            endpoint,
+				Region:              "us-west-2",
+				AWSEndpoint:         upstream.URL,
+				CertificateFilePath: tt.certificateFilePath,
+				NoVerifySSL:         tt.noVerifySSL,
+			}, zap.NewNop())
+			require.NoError(t, err)
+			require.NoError(t, p.Start(component.NewMockHost()))
+			defer p.Shutdown()
+
+			resp, err := http.Post("http://"+endpoint+"/GetSamplingRules", "application/json", bytes.NewReader([]byte("{}")))
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestUpstreamUnavailable(t *testing.T) {
+	defer setEnv(t, "AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")()
+	defer setEnv(t, "AWS_SECRET_ACCESS_KEY", "secret")()
+
+	endpoint := testutils.GetAvailableLocalAddress(t)
+	p, err := newAWSProxy(&Config{
+		Endpoint:    endpoint,
+		Region:      "us-west-2",
+		AWSEndpoint: "http://" + testutils.GetAvailableLocalAddress(t),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(component.NewMockHost()))
+	defer p.Shutdown()
+
+	resp, err := http.Post("http://"+endpoint+"/GetSamplingRules", "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
@@ -0,0 +1,50 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsproxy
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the AWS proxy extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Endpoint is the address and port the extension listens on. The default
+	// value is "localhost:2000", the port used by the X-Ray SDKs.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// ProxyAddress is the URL of an HTTPS proxy used to reach AWS. Optional.
+	ProxyAddress string `mapstructure:"proxy_address"`
+
+	// Region is the AWS region the requests are sent to. By default it is
+	// taken from the environment or, on EC2, from the instance metadata.
+	Region string `mapstructure:"region"`
+
+	// RoleARN is the IAM role assumed to sign the requests. Optional.
+	RoleARN string `mapstructure:"role_arn"`
+
+	// AWSEndpoint overrides the URL of the X-Ray API, eg.: to use a VPC
+	// endpoint. By default it is derived from the region.
+	AWSEndpoint string `mapstructure:"aws_endpoint"`
+
+	// CertificateFilePath is the path to a PEM file with the CA certificates
+	// used to verify AWS. By default the system ones are used.
+	CertificateFilePath string `mapstructure:"certificate_file_path"`
+
+	// NoVerifySSL disables the verification of the TLS certificate of AWS.
+	// The default value is false.
+	NoVerifySSL bool `mapstructure:"no_verify_ssl"`
+}
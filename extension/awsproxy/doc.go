@@ -0,0 +1,19 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package awsproxy implements an extension that forwards the requests it
+// receives to the AWS X-Ray API, signing them with AWS Signature Version 4.
+// It allows the X-Ray SDKs to use centralized sampling when only the
+// Collector holds AWS credentials.
+package awsproxy
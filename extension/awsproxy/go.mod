@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/awsproxy
+
+go 1.12
+
+require (
+	github.com/aws/aws-sdk-go v1.23.12
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil v0.0.0
+	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.13.0
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil => ../../internal/aws/awsutil
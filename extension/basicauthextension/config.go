@@ -0,0 +1,54 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the basic auth extension. Exactly one of
+// Htpasswd and ClientAuth must be set: the former makes the extension a
+// server authenticator, the latter a client authenticator.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Htpasswd holds the credentials accepted from clients.
+	Htpasswd *HtpasswdSettings `mapstructure:"htpasswd"`
+
+	// ClientAuth holds the credentials sent to servers.
+	ClientAuth *ClientAuthSettings `mapstructure:"client_auth"`
+}
+
+// HtpasswdSettings defines the credentials accepted by the server
+// authenticator, in htpasswd format. Both fields can be used together, their
+// contents are merged.
+type HtpasswdSettings struct {
+	// File is the path to an htpasswd file.
+	File string `mapstructure:"file"`
+
+	// Inline holds the htpasswd entries directly in the configuration, one
+	// per line.
+	Inline string `mapstructure:"inline"`
+}
+
+// ClientAuthSettings defines the credentials added by the client
+// authenticator.
+type ClientAuthSettings struct {
+	// Username is the user name sent to the server.
+	Username string `mapstructure:"username"`
+
+	// Password is the password sent to the server.
+	Password string `mapstructure:"password"`
+}
@@ -0,0 +1,207 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/tg123/go-htpasswd"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+type usernameKey struct{}
+
+// serverAuthExtension validates the basic auth credentials of incoming
+// requests against htpasswd entries.
+type serverAuthExtension struct {
+	settings *HtpasswdSettings
+	logger   *zap.Logger
+	matchFn  func(username, password string) bool
+}
+
+var _ auth.ServerAuthenticator = (*serverAuthExtension)(nil)
+
+func newServerAuthExtension(settings *HtpasswdSettings, logger *zap.Logger) (*serverAuthExtension, error) {
+	if settings.File == "" && settings.Inline == "" {
+		return nil, errNoHtpasswdSource
+	}
+	return &serverAuthExtension{
+		settings: settings,
+		logger:   logger,
+	}, nil
+}
+
+// Start loads the htpasswd entries.
+func (s *serverAuthExtension) Start(host component.Host) error {
+	var readers []io.Reader
+	if s.settings.File != "" {
+		f, err := os.Open(s.settings.File)
+		if err != nil {
+			return fmt.Errorf("failed to open htpasswd file: %v", err)
+		}
+		defer f.Close()
+		readers = append(readers, f, strings.NewReader("\n"))
+	}
+	if s.settings.Inline != "" {
+		readers = append(readers, strings.NewReader(s.settings.Inline))
+	}
+
+	badLine := func(err error) {
+		s.logger.Warn("Ignoring invalid htpasswd entry", zap.Error(err))
+	}
+	htp, err := htpasswd.NewFromReader(io.MultiReader(readers...), htpasswd.DefaultSystems, badLine)
+	if err != nil {
+		return fmt.Errorf("failed to read htpasswd entries: %v", err)
+	}
+	s.matchFn = htp.Match
+	return nil
+}
+
+// Shutdown is a no-op.
+func (s *serverAuthExtension) Shutdown() error {
+	return nil
+}
+
+// Authenticate checks the credentials of the "Authorization" header. The
+// returned context carries the user name, see UsernameFromContext.
+func (s *serverAuthExtension) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	var values []string
+	for k, v := range headers {
+		// HTTP canonicalizes the header names while gRPC lowercases them.
+		if strings.EqualFold(k, "authorization") {
+			values = v
+			break
+		}
+	}
+	if len(values) == 0 {
+		return ctx, auth.ErrNotAuthenticated
+	}
+
+	username, password, ok := parseBasicAuth(values[0])
+	if !ok {
+		return ctx, fmt.Errorf("%v: invalid basic auth header", auth.ErrNotAuthenticated)
+	}
+	if !s.matchFn(username, password) {
+		return ctx, fmt.Errorf("%v: invalid credentials", auth.ErrNotAuthenticated)
+	}
+	return context.WithValue(ctx, usernameKey{}, username), nil
+}
+
+// parseBasicAuth parses an HTTP Basic Authentication header value, see
+// net/http.Request.BasicAuth.
+func parseBasicAuth(value string) (username, password string, ok bool) {
+	const prefix = "basic "
+	if len(value) < len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value[len(prefix):])
+	if err != nil {
+		return "", "", false
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// UsernameFromContext returns the user name authenticated by the extension,
+// if any.
+func UsernameFromContext(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(usernameKey{}).(string)
+	return username, ok
+}
+
+// clientAuthExtension adds basic auth credentials to outgoing requests.
+type clientAuthExtension struct {
+	authorization string
+}
+
+var _ auth.ClientAuthenticator = (*clientAuthExtension)(nil)
+
+func newClientAuthExtension(settings *ClientAuthSettings) (*clientAuthExtension, error) {
+	if settings.Username == "" {
+		return nil, errNoUsername
+	}
+	userPass := settings.Username + ":" + settings.Password
+	return &clientAuthExtension{
+		authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte(userPass)),
+	}, nil
+}
+
+// Start is a no-op.
+func (c *clientAuthExtension) Start(host component.Host) error {
+	return nil
+}
+
+// Shutdown is a no-op.
+func (c *clientAuthExtension) Shutdown() error {
+	return nil
+}
+
+// RoundTripper returns a round tripper setting the "Authorization" header of
+// the requests.
+func (c *clientAuthExtension) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &basicAuthRoundTripper{
+		base:          base,
+		authorization: c.authorization,
+	}, nil
+}
+
+// PerRPCCredentials returns gRPC credentials carrying the basic auth header.
+func (c *clientAuthExtension) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCAuth{authorization: c.authorization}, nil
+}
+
+type basicAuthRoundTripper struct {
+	base          http.RoundTripper
+	authorization string
+}
+
+func (b *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Round trippers must not modify the original request.
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		newReq.Header[k] = v
+	}
+	newReq.Header.Set("Authorization", b.authorization)
+	return b.base.RoundTrip(newReq)
+}
+
+type perRPCAuth struct {
+	authorization string
+}
+
+func (p *perRPCAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": p.authorization}, nil
+}
+
+// RequireTransportSecurity always returns true since the credentials are
+// sent in clear text.
+func (p *perRPCAuth) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,155 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestServerAuthenticate(t *testing.T) {
+	ext, err := newServerAuthExtension(&HtpasswdSettings{
+		File:   path.Join(".", "testdata", "htpasswd"),
+		Inline: "inline:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=",
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	defer ext.Shutdown()
+
+	tests := []struct {
+		name     string
+		header   string
+		username string
+		wantErr  bool
+	}{
+		{
+			name:     "file_entry",
+			header:   basicAuthHeader("username", "password"),
+			username: "username",
+		},
+		{
+			name:     "inline_entry",
+			header:   basicAuthHeader("inline", "secret"),
+			username: "inline",
+		},
+		{
+			name:    "wrong_password",
+			header:  basicAuthHeader("username", "secret"),
+			wantErr: true,
+		},
+		{
+			name:    "unknown_user",
+			header:  basicAuthHeader("unknown", "password"),
+			wantErr: true,
+		},
+		{
+			name:    "not_basic",
+			header:  "Bearer token",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_encoding",
+			header:  "Basic !!!",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := ext.Authenticate(context.Background(), map[string][]string{
+				"authorization": {tt.header},
+			})
+			username, ok := UsernameFromContext(ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.username, username)
+		})
+	}
+
+	_, err = ext.Authenticate(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestServerStartMissingFile(t *testing.T) {
+	ext, err := newServerAuthExtension(&HtpasswdSettings{
+		File: path.Join(".", "testdata", "missing"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, ext.Start(component.NewMockHost()))
+}
+
+func TestClientRoundTripper(t *testing.T) {
+	var username, password string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+	}))
+	defer server.Close()
+
+	ext, err := newClientAuthExtension(&ClientAuthSettings{
+		Username: "username",
+		Password: "password",
+	})
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	defer ext.Shutdown()
+
+	rt, err := ext.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "username", username)
+	assert.Equal(t, "password", password)
+	// The original request must not be modified.
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestClientPerRPCCredentials(t *testing.T) {
+	ext, err := newClientAuthExtension(&ClientAuthSettings{
+		Username: "username",
+		Password: "password",
+	})
+	require.NoError(t, err)
+
+	creds, err := ext.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": basicAuthHeader("username", "password")}, md)
+}
+
+func basicAuthHeader(username, password string) string {
+	req := &http.Request{Header: http.Header{}}
+	req.SetBasicAuth(username, password)
+	return req.Header.Get("Authorization")
+}
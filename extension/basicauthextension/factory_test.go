@@ -0,0 +1,82 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configcheck"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+func TestFactory_Type(t *testing.T) {
+	factory := Factory{}
+	require.Equal(t, typeStr, factory.Type())
+}
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				NameVal: typeStr,
+				TypeVal: typeStr,
+			},
+		},
+		cfg)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+	assert.Equal(t, errNoCredentialSource, err)
+	assert.Nil(t, ext)
+
+	cfg.Htpasswd = &HtpasswdSettings{}
+	cfg.ClientAuth = &ClientAuthSettings{}
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	assert.Equal(t, errMultipleAuthenticators, err)
+	assert.Nil(t, ext)
+
+	cfg.ClientAuth = nil
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	assert.Equal(t, errNoHtpasswdSource, err)
+	assert.Nil(t, ext)
+
+	cfg.Htpasswd.Inline = "username:password"
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	assert.Implements(t, (*auth.ServerAuthenticator)(nil), ext)
+
+	cfg.Htpasswd = nil
+	cfg.ClientAuth = &ClientAuthSettings{}
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	assert.Equal(t, errNoUsername, err)
+	assert.Nil(t, ext)
+
+	cfg.ClientAuth.Username = "username"
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	assert.Implements(t, (*auth.ClientAuthenticator)(nil), ext)
+}
@@ -0,0 +1,36 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the bearer token auth extension. Exactly
+// one of BearerToken and Filename must be set.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Scheme is the authentication scheme prefixed to the token in the
+	// "Authorization" header. The default value is "Bearer".
+	Scheme string `mapstructure:"scheme"`
+
+	// BearerToken is the token to use.
+	BearerToken string `mapstructure:"token"`
+
+	// Filename is the path to a file holding the token, eg.: a Kubernetes
+	// service account token. The file is re-read whenever it changes.
+	Filename string `mapstructure:"filename"`
+}
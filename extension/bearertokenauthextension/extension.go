@@ -0,0 +1,214 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+// bearerTokenAuth adds a bearer token to outgoing requests. When the token
+// comes from a file, the directory holding it is watched: Kubernetes, for
+// instance, rotates the projected tokens by swapping a symlink, which isn't
+// reported as an event on the file itself.
+type bearerTokenAuth struct {
+	scheme   string
+	filename string
+	logger   *zap.Logger
+
+	// authorization holds the current value of the "Authorization" header.
+	authorization atomic.Value
+
+	watcher *fsnotify.Watcher
+	wg      sync.WaitGroup
+}
+
+var _ auth.ClientAuthenticator = (*bearerTokenAuth)(nil)
+
+var errTokenNotLoaded = errors.New("the token file hasn't been read, the extension must be started first")
+
+func newBearerTokenAuth(cfg *Config, logger *zap.Logger) (*bearerTokenAuth, error) {
+	if cfg.BearerToken != "" && cfg.Filename != "" {
+		return nil, errMultipleTokenSources
+	}
+	if cfg.BearerToken == "" && cfg.Filename == "" {
+		return nil, errNoTokenProvided
+	}
+
+	b := &bearerTokenAuth{
+		scheme:   cfg.Scheme,
+		filename: cfg.Filename,
+		logger:   logger,
+	}
+	if b.scheme == "" {
+		b.scheme = defaultScheme
+	}
+	if cfg.BearerToken != "" {
+		b.setToken(cfg.BearerToken)
+	}
+	return b, nil
+}
+
+// Start reads the token file, if any, and starts watching it.
+func (b *bearerTokenAuth) Start(host component.Host) error {
+	if b.filename == "" {
+		return nil
+	}
+
+	if err := b.refreshToken(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create the token file watcher: %v", err)
+	}
+	if err := watcher.Add(filepath.Dir(b.filename)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch the token file: %v", err)
+	}
+	b.watcher = watcher
+
+	b.wg.Add(1)
+	go b.watch()
+	return nil
+}
+
+// Shutdown stops watching the token file.
+func (b *bearerTokenAuth) Shutdown() error {
+	if b.watcher == nil {
+		return nil
+	}
+	err := b.watcher.Close()
+	b.wg.Wait()
+	return err
+}
+
+func (b *bearerTokenAuth) watch() {
+	defer b.wg.Done()
+	for {
+		select {
+		case _, ok := <-b.watcher.Events:
+			if !ok {
+				return
+			}
+			// Any change in the directory may be a rotation of the token,
+			// re-reading a small file is cheap.
+			if err := b.refreshToken(); err != nil {
+				b.logger.Warn("Failed to refresh the token, keeping the previous one", zap.Error(err))
+			}
+		case err, ok := <-b.watcher.Errors:
+			if !ok {
+				return
+			}
+			b.logger.Warn("Error watching the token file", zap.Error(err))
+		}
+	}
+}
+
+func (b *bearerTokenAuth) refreshToken() error {
+	content, err := ioutil.ReadFile(b.filename)
+	if err != nil {
+		return fmt.Errorf("failed to read the token file: %v", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return fmt.Errorf("token file %q is empty", b.filename)
+	}
+	b.setToken(token)
+	return nil
+}
+
+func (b *bearerTokenAuth) setToken(token string) {
+	b.authorization.Store(b.scheme + " " + token)
+}
+
+// authorizationHeader returns the value of the "Authorization" header. It
+// fails if the token file hasn't been read yet.
+func (b *bearerTokenAuth) authorizationHeader() (string, error) {
+	authorization, ok := b.authorization.Load().(string)
+	if !ok {
+		return "", errTokenNotLoaded
+	}
+	return authorization, nil
+}
+
+// RoundTripper returns a round tripper setting the "Authorization" header of
+// the requests to the current token.
+func (b *bearerTokenAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &bearerAuthRoundTripper{
+		base: base,
+		auth: b,
+	}, nil
+}
+
+// PerRPCCredentials returns gRPC credentials carrying the current token.
+func (b *bearerTokenAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCAuth{auth: b}, nil
+}
+
+type bearerAuthRoundTripper struct {
+	base http.RoundTripper
+	auth *bearerTokenAuth
+}
+
+func (r *bearerAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	authorization, err := r.auth.authorizationHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	// Round trippers must not modify the original request.
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		newReq.Header[k] = v
+	}
+	newReq.Header.Set("Authorization", authorization)
+	return r.base.RoundTrip(newReq)
+}
+
+type perRPCAuth struct {
+	auth *bearerTokenAuth
+}
+
+func (p *perRPCAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	authorization, err := p.auth.authorizationHeader()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": authorization}, nil
+}
+
+// RequireTransportSecurity always returns true since the token is sent in
+// clear text.
+func (p *perRPCAuth) RequireTransportSecurity() bool {
+	return true
+}
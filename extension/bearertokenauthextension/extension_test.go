@@ -0,0 +1,135 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStaticToken(t *testing.T) {
+	b, err := newBearerTokenAuth(&Config{
+		Scheme:      defaultScheme,
+		BearerToken: "sometoken",
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, b.Start(component.NewMockHost()))
+	defer b.Shutdown()
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	rt, err := b.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "Bearer sometoken", authorization)
+	// The original request must not be modified.
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	creds, err := b.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer sometoken"}, md)
+}
+
+func TestTokenFileReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bearertokenauth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(filename, []byte("first\n"), 0600))
+
+	b, err := newBearerTokenAuth(&Config{
+		Scheme:   "MyScheme",
+		Filename: filename,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, b.Start(component.NewMockHost()))
+	defer b.Shutdown()
+
+	creds, err := b.PerRPCCredentials()
+	require.NoError(t, err)
+	authorization := func() string {
+		md, err := creds.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		return md["authorization"]
+	}
+	assert.Equal(t, "MyScheme first", authorization())
+
+	// Rotate the token the way Kubernetes does: write a new file and
+	// rename it over the old one.
+	tmp := filepath.Join(dir, "token.tmp")
+	require.NoError(t, ioutil.WriteFile(tmp, []byte("second"), 0600))
+	require.NoError(t, os.Rename(tmp, filename))
+
+	assert.Eventually(t, func() bool {
+		return authorization() == "MyScheme second"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// An invalid content keeps the previous token.
+	require.NoError(t, ioutil.WriteFile(filename, []byte(""), 0600))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "MyScheme second", authorization())
+}
+
+func TestTokenFileNotStarted(t *testing.T) {
+	b, err := newBearerTokenAuth(&Config{
+		Filename: filepath.Join("testdata", "missing"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	rt, err := b.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	assert.Equal(t, errTokenNotLoaded, err)
+
+	creds, err := b.PerRPCCredentials()
+	require.NoError(t, err)
+	_, err = creds.GetRequestMetadata(context.Background())
+	assert.Equal(t, errTokenNotLoaded, err)
+}
+
+func TestStartMissingFile(t *testing.T) {
+	b, err := newBearerTokenAuth(&Config{
+		Filename: filepath.Join("testdata", "missing"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, b.Start(component.NewMockHost()))
+	assert.NoError(t, b.Shutdown())
+}
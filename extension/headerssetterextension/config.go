@@ -0,0 +1,42 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headerssetterextension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the headers setter extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Headers lists the headers to set on the outgoing requests.
+	Headers []HeaderConfig `mapstructure:"headers"`
+}
+
+// HeaderConfig defines a header to set. Exactly one of Value and FromContext
+// must be set.
+type HeaderConfig struct {
+	// Key is the name of the header.
+	Key string `mapstructure:"key"`
+
+	// Value is a static value for the header.
+	Value string `mapstructure:"value"`
+
+	// FromContext is the name of the header, or gRPC metadata key, of the
+	// incoming request holding the value. The header isn't set if the
+	// incoming request didn't have it.
+	FromContext string `mapstructure:"from_context"`
+}
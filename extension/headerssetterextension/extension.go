@@ -0,0 +1,134 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headerssetterextension
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/client"
+)
+
+// headersSetter sets headers on outgoing requests. The values taken from the
+// incoming request are looked up in the context of the outgoing one, see
+// the client package.
+type headersSetter struct {
+	headers []HeaderConfig
+}
+
+var _ auth.ClientAuthenticator = (*headersSetter)(nil)
+
+func newHeadersSetter(cfg *Config) (*headersSetter, error) {
+	if len(cfg.Headers) == 0 {
+		return nil, errNoHeaders
+	}
+	for _, h := range cfg.Headers {
+		if h.Key == "" {
+			return nil, errNoKey
+		}
+		if h.Value == "" && h.FromContext == "" {
+			return nil, errNoValueSource
+		}
+		if h.Value != "" && h.FromContext != "" {
+			return nil, errMultipleSources
+		}
+	}
+	return &headersSetter{headers: cfg.Headers}, nil
+}
+
+// Start is a no-op.
+func (h *headersSetter) Start(host component.Host) error {
+	return nil
+}
+
+// Shutdown is a no-op.
+func (h *headersSetter) Shutdown() error {
+	return nil
+}
+
+// RoundTripper returns a round tripper setting the headers of the requests.
+func (h *headersSetter) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &headersRoundTripper{
+		base:   base,
+		setter: h,
+	}, nil
+}
+
+// PerRPCCredentials returns gRPC credentials setting the headers as metadata
+// of the calls.
+func (h *headersSetter) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &headersPerRPC{setter: h}, nil
+}
+
+// values returns the headers to set for a request sent with the context.
+func (h *headersSetter) values(ctx context.Context) map[string]string {
+	md, _ := client.FromContext(ctx)
+	values := make(map[string]string, len(h.headers))
+	for _, header := range h.headers {
+		if header.Value != "" {
+			values[header.Key] = header.Value
+			continue
+		}
+		if v := md.Get(header.FromContext); len(v) > 0 {
+			values[header.Key] = v[0]
+		}
+	}
+	return values
+}
+
+type headersRoundTripper struct {
+	base   http.RoundTripper
+	setter *headersSetter
+}
+
+func (r *headersRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	values := r.setter.values(req.Context())
+
+	// Round trippers must not modify the original request.
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.Header = make(http.Header, len(req.Header)+len(values))
+	for k, v := range req.Header {
+		newReq.Header[k] = v
+	}
+	for k, v := range values {
+		newReq.Header.Set(k, v)
+	}
+	return r.base.RoundTrip(newReq)
+}
+
+type headersPerRPC struct {
+	setter *headersSetter
+}
+
+func (p *headersPerRPC) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	values := p.setter.values(ctx)
+	md := make(map[string]string, len(values))
+	for k, v := range values {
+		// HTTP/2 requires lowercase header names.
+		md[strings.ToLower(k)] = v
+	}
+	return md, nil
+}
+
+// RequireTransportSecurity returns false, the headers aren't credentials.
+func (p *headersPerRPC) RequireTransportSecurity() bool {
+	return false
+}
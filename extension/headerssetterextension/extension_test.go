@@ -0,0 +1,89 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headerssetterextension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/client"
+)
+
+func newTestHeadersSetter(t *testing.T) *headersSetter {
+	h, err := newHeadersSetter(&Config{
+		Headers: []HeaderConfig{
+			{Key: "X-Scope-OrgID", FromContext: "X-Tenant"},
+			{Key: "User-ID", Value: "user_id"},
+		},
+	})
+	require.NoError(t, err)
+	return h
+}
+
+func TestRoundTripper(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header
+	}))
+	defer server.Close()
+
+	h := newTestHeadersSetter(t)
+	rt, err := h.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	httpClient := &http.Client{Transport: rt}
+
+	incoming := http.Header{}
+	incoming.Set("X-Tenant", "acme")
+	ctx := client.NewContext(context.Background(), client.Metadata(incoming))
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "acme", received.Get("X-Scope-OrgID"))
+	assert.Equal(t, "user_id", received.Get("User-ID"))
+	// The original request must not be modified.
+	assert.Empty(t, req.Header.Get("X-Scope-OrgID"))
+
+	// Headers missing from the incoming request aren't set.
+	req, err = http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, ok := received["X-Scope-Orgid"]
+	assert.False(t, ok)
+	assert.Equal(t, "user_id", received.Get("User-ID"))
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	h := newTestHeadersSetter(t)
+	creds, err := h.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.False(t, creds.RequireTransportSecurity())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "acme"))
+	md, err := creds.GetRequestMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"x-scope-orgid": "acme", "user-id": "user_id"}, md)
+}
@@ -0,0 +1,71 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headerssetterextension
+
+import (
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/open-telemetry/opentelemetry-collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "headers_setter"
+)
+
+var (
+	errNoHeaders       = errors.New("\"headers\" config cannot be empty")
+	errNoKey           = errors.New("header \"key\" cannot be empty")
+	errNoValueSource   = errors.New("either \"value\" or \"from_context\" must be set for each header")
+	errMultipleSources = errors.New("only one of \"value\" or \"from_context\" can be set for each header")
+)
+
+// Factory is the factory for the headers setter extension.
+type Factory struct {
+}
+
+var _ extension.Factory = (*Factory)(nil)
+
+// Type gets the type of the config created by this factory.
+func (f *Factory) Type() string {
+	return typeStr
+}
+
+// CreateDefaultConfig creates the default configuration for the extension.
+func (f *Factory) CreateDefaultConfig() configmodels.Extension {
+	return &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+	}
+}
+
+// CreateExtension creates the extension based on this config.
+func (f *Factory) CreateExtension(
+	logger *zap.Logger,
+	cfg configmodels.Extension,
+) (extension.ServiceExtension, error) {
+	ext, err := newHeadersSetter(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewClientAuthenticatorExtension(cfg.Name(), ext), nil
+}
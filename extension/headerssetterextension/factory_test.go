@@ -0,0 +1,92 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headerssetterextension
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configcheck"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFactory_Type(t *testing.T) {
+	factory := Factory{}
+	require.Equal(t, typeStr, factory.Type())
+}
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				NameVal: typeStr,
+				TypeVal: typeStr,
+			},
+		},
+		cfg)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	factory := Factory{}
+
+	tests := []struct {
+		name    string
+		headers []HeaderConfig
+		err     error
+	}{
+		{
+			name: "no_headers",
+			err:  errNoHeaders,
+		},
+		{
+			name:    "no_key",
+			headers: []HeaderConfig{{Value: "value"}},
+			err:     errNoKey,
+		},
+		{
+			name:    "no_value",
+			headers: []HeaderConfig{{Key: "key"}},
+			err:     errNoValueSource,
+		},
+		{
+			name:    "multiple_sources",
+			headers: []HeaderConfig{{Key: "key", Value: "value", FromContext: "key"}},
+			err:     errMultipleSources,
+		},
+		{
+			name:    "valid",
+			headers: []HeaderConfig{{Key: "key", Value: "value"}, {Key: "tenant", FromContext: "tenant"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Headers = tt.headers
+			ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				assert.Nil(t, ext)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, ext)
+		})
+	}
+}
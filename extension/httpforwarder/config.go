@@ -0,0 +1,56 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpforwarder
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the HTTP forwarder extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Ingress holds the settings of the HTTP server listening for the requests
+	// to be forwarded.
+	Ingress IngressConfig `mapstructure:"ingress"`
+
+	// Egress holds the settings used when forwarding the requests.
+	Egress EgressConfig `mapstructure:"egress"`
+}
+
+// IngressConfig defines the settings of the server receiving the requests.
+type IngressConfig struct {
+	// Endpoint is the address and port the extension listens on. The default
+	// value is "localhost:6060".
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// EgressConfig defines the settings used to forward the requests.
+type EgressConfig struct {
+	// Endpoint is the URL to where the requests are forwarded to, it must
+	// include the scheme and host. The path of the incoming requests is
+	// appended to the path of this URL. Has no default value.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Headers are added to the forwarded requests, replacing any header with
+	// the same name sent by the client, eg.: an access token.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Timeout is the maximum duration of a forwarded request. The default
+	// value is 10 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
@@ -0,0 +1,9 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder
+
+go 1.12
+
+require (
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.13.0
+)
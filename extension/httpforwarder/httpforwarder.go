@@ -0,0 +1,130 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpforwarder
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/open-telemetry/opentelemetry-collector/extension"
+	"go.uber.org/zap"
+)
+
+// httpForwarder listens for HTTP requests and forwards them, with the
+// configured headers, to the egress endpoint.
+type httpForwarder struct {
+	logger   *zap.Logger
+	endpoint string
+	timeout  time.Duration
+	proxy    *httputil.ReverseProxy
+	server   *http.Server
+}
+
+var _ extension.ServiceExtension = (*httpForwarder)(nil)
+
+func newHTTPForwarder(config *Config, logger *zap.Logger) (*httpForwarder, error) {
+	if config.Egress.Endpoint == "" {
+		return nil, errEmptyEgressEndpoint
+	}
+
+	forwardTo, err := url.Parse(config.Egress.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%q invalid \"egress.endpoint\": %v", config.Name(), err)
+	}
+	if forwardTo.Scheme == "" || forwardTo.Host == "" {
+		return nil, fmt.Errorf(
+			"%q \"egress.endpoint\" must include the scheme and host: %q",
+			config.Name(),
+			config.Egress.Endpoint)
+	}
+
+	if config.Egress.Timeout < 0 {
+		return nil, fmt.Errorf("%q config cannot have a negative \"egress.timeout\"", config.Name())
+	}
+
+	h := &httpForwarder{
+		logger:   logger,
+		endpoint: config.Ingress.Endpoint,
+		timeout:  config.Egress.Timeout,
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(forwardTo)
+	director := proxy.Director
+	headers := config.Egress.Headers
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		// Use the host of the upstream, the one from the client refers to the
+		// extension itself.
+		req.Host = forwardTo.Host
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+	}
+	proxy.ErrorHandler = h.handleError
+	h.proxy = proxy
+
+	return h, nil
+}
+
+// Start starts listening for requests to forward.
+func (h *httpForwarder) Start(host component.Host) error {
+	listener, err := net.Listen("tcp", h.endpoint)
+	if err != nil {
+		return err
+	}
+
+	h.server = &http.Server{
+		Handler: http.HandlerFunc(h.forwardRequest),
+	}
+	go func() {
+		if err := h.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown stops the server, requests being forwarded are interrupted.
+func (h *httpForwarder) Shutdown() error {
+	if h.server == nil {
+		return nil
+	}
+	return h.server.Close()
+}
+
+func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http.Request) {
+	if h.timeout > 0 {
+		ctx, cancel := context.WithTimeout(request.Context(), h.timeout)
+		defer cancel()
+		request = request.WithContext(ctx)
+	}
+	h.proxy.ServeHTTP(writer, request)
+}
+
+func (h *httpForwarder) handleError(writer http.ResponseWriter, request *http.Request, err error) {
+	h.logger.Debug(
+		"Failed to forward request",
+		zap.String("method", request.Method),
+		zap.String("path", request.URL.Path),
+		zap.Error(err))
+	writer.WriteHeader(http.StatusBadGateway)
+}
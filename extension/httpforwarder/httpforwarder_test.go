@@ -0,0 +1,162 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpforwarder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/open-telemetry/opentelemetry-collector/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestForwardRequest(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/base/v2/apm/correlate", r.URL.Path)
+		assert.Equal(t, "k=v", r.URL.RawQuery)
+		assert.Equal(t, "token", r.Header.Get("X-Sf-Token"))
+		assert.Equal(t, "client", r.Header.Get("X-Client-Header"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "request body", string(body))
+
+		w.Header().Set("X-Backend-Header", "backend")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, "response body")
+	}))
+	defer backend.Close()
+
+	addr := testutils.GetAvailableLocalAddress(t)
+	hf, err := newHTTPForwarder(&Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Ingress: IngressConfig{Endpoint: addr},
+		Egress: EgressConfig{
+			Endpoint: backend.URL + "/base",
+			Headers: map[string]string{
+				"X-Sf-Token": "token",
+			},
+			Timeout: 5 * time.Second,
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, hf.Start(component.NewMockHost()))
+	defer func() {
+		assert.NoError(t, hf.Shutdown())
+	}()
+
+	req, err := http.NewRequest(
+		http.MethodPut,
+		fmt.Sprintf("http://%s/v2/apm/correlate?k=v", addr),
+		strings.NewReader("request body"))
+	require.NoError(t, err)
+	req.Header.Set("X-Client-Header", "client")
+	req.Header.Set("X-Sf-Token", "to be replaced")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "backend", resp.Header.Get("X-Backend-Header"))
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "response body", string(body))
+}
+
+func TestForwardRequestBackendDown(t *testing.T) {
+	backend := httptest.NewServer(http.NotFoundHandler())
+	backendURL := backend.URL
+	backend.Close()
+
+	addr := testutils.GetAvailableLocalAddress(t)
+	hf, err := newHTTPForwarder(&Config{
+		Ingress: IngressConfig{Endpoint: addr},
+		Egress:  EgressConfig{Endpoint: backendURL},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, hf.Start(component.NewMockHost()))
+	defer hf.Shutdown()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/", addr))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func TestNewHTTPForwarderErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+	}{
+		{
+			name:   "empty_endpoint",
+			config: &Config{},
+		},
+		{
+			name: "no_scheme",
+			config: &Config{
+				Egress: EgressConfig{Endpoint: "localhost:9090"},
+			},
+		},
+		{
+			name: "invalid_url",
+			config: &Config{
+				Egress: EgressConfig{Endpoint: "http://local\x7fhost"},
+			},
+		},
+		{
+			name: "negative_timeout",
+			config: &Config{
+				Egress: EgressConfig{
+					Endpoint: "http://localhost:9090",
+					Timeout:  -time.Second,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hf, err := newHTTPForwarder(tt.config, zap.NewNop())
+			assert.Error(t, err)
+			assert.Nil(t, hf)
+		})
+	}
+}
+
+func TestStartInvalidEndpoint(t *testing.T) {
+	hf, err := newHTTPForwarder(&Config{
+		Ingress: IngressConfig{Endpoint: "invalid:endpoint:1"},
+		Egress:  EgressConfig{Endpoint: "http://localhost:9090"},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, hf.Start(component.NewMockHost()))
+	assert.NoError(t, hf.Shutdown())
+}
@@ -0,0 +1,81 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the OAuth2 client credentials extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// ClientID is the application's ID. Has no default value.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret is the application's secret. Has no default value.
+	ClientSecret string `mapstructure:"client_secret"`
+
+	// TokenURL is the resource server's token endpoint URL. Has no default
+	// value.
+	TokenURL string `mapstructure:"token_url"`
+
+	// Scopes optionally specifies a list of requested permission scopes.
+	Scopes []string `mapstructure:"scopes"`
+
+	// Audience optionally specifies the intended audience of the token, it is
+	// sent as the "audience" parameter of the token requests.
+	Audience string `mapstructure:"audience"`
+
+	// Timeout is the maximum duration of the requests made to the token
+	// endpoint. The default value is 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TLS configures the connection to the token endpoint, eg.: the client
+	// certificate for authorization servers requiring mutual TLS.
+	TLS TLSSettings `mapstructure:"tls"`
+
+	// RefreshMargin is how long before the current token expires a new one
+	// is requested. Tokens living less than twice the margin are refreshed
+	// at half their lifetime. The default value is 1 minute.
+	RefreshMargin time.Duration `mapstructure:"refresh_margin"`
+
+	// Retry configures the backoff between failed token requests.
+	Retry RetrySettings `mapstructure:"retry"`
+}
+
+// TLSSettings holds the files used to connect to the token endpoint.
+type TLSSettings struct {
+	// CAFile is the CA used to verify the token endpoint, by default the
+	// system roots are used.
+	CAFile string `mapstructure:"ca_file"`
+	// CertFile is the client certificate, it must be set with KeyFile.
+	CertFile string `mapstructure:"cert_file"`
+	// KeyFile is the key of the client certificate.
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// RetrySettings defines the exponential backoff between failed token
+// requests.
+type RetrySettings struct {
+	// InitialInterval is the wait after the first failure, it is doubled
+	// after each consecutive failure. The default value is 1 second.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval is the upper bound of the wait. The default value is 30
+	// seconds.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+}
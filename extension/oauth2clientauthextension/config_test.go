@@ -0,0 +1,74 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := config.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := &Factory{}
+	factories.Extensions[typeStr] = factory
+	cfg, err := config.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	ext0 := cfg.Extensions["oauth2client"]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.ClientID = "someclientid"
+	defaultCfg.ClientSecret = "someclientsecret"
+	defaultCfg.TokenURL = "https://example.com/oauth2/default/v1/token"
+	assert.Equal(t, defaultCfg, ext0)
+
+	ext1 := cfg.Extensions["oauth2client/withscopes"]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				TypeVal: typeStr,
+				NameVal: "oauth2client/withscopes",
+			},
+			ClientID:     "someclientid",
+			ClientSecret: "someclientsecret",
+			TokenURL:     "https://example.com/oauth2/default/v1/token",
+			Scopes:       []string{"api.metrics", "api.traces"},
+			Audience:     "https://api.example.com",
+			Timeout:      time.Second,
+			TLS: TLSSettings{
+				CAFile:   "/var/lib/mycert/ca.pem",
+				CertFile: "/var/lib/mycert/cert.pem",
+				KeyFile:  "/var/lib/mycert/key.pem",
+			},
+			RefreshMargin: 5 * time.Minute,
+			Retry: RetrySettings{
+				InitialInterval: 2 * time.Second,
+				MaxInterval:     time.Minute,
+			},
+		},
+		ext1)
+
+	assert.Equal(t, 1, len(cfg.Service.Extensions))
+	assert.Equal(t, "oauth2client/withscopes", cfg.Service.Extensions[0])
+}
@@ -0,0 +1,175 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc/credentials"
+	grpcoauth "google.golang.org/grpc/credentials/oauth"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+// clientCredentialsAuthenticator adds the tokens obtained with the OAuth2
+// client credentials flow to outgoing requests.
+type clientCredentialsAuthenticator struct {
+	clientCredentials *clientcredentials.Config
+	tokenSource       *refreshingTokenSource
+}
+
+var _ auth.ClientAuthenticator = (*clientCredentialsAuthenticator)(nil)
+
+func newClientCredentialsAuthenticator(cfg *Config, logger *zap.Logger) (*clientCredentialsAuthenticator, error) {
+	if cfg.ClientID == "" {
+		return nil, errNoClientIDProvided
+	}
+	// Clients authenticated by their TLS certificate don't have a secret,
+	// see RFC 8705.
+	if cfg.ClientSecret == "" && cfg.TLS.CertFile == "" {
+		return nil, errNoClientSecretProvided
+	}
+	if cfg.TokenURL == "" {
+		return nil, errNoTokenURLProvided
+	}
+	if cfg.RefreshMargin < 0 {
+		return nil, errNegativeRefreshMargin
+	}
+	if cfg.Retry.InitialInterval <= 0 || cfg.Retry.InitialInterval > cfg.Retry.MaxInterval {
+		return nil, errInvalidRetry
+	}
+
+	a := &clientCredentialsAuthenticator{
+		clientCredentials: &clientcredentials.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			TokenURL:     cfg.TokenURL,
+			Scopes:       cfg.Scopes,
+		},
+	}
+	if cfg.Audience != "" {
+		a.clientCredentials.EndpointParams = url.Values{"audience": {cfg.Audience}}
+	}
+	if cfg.ClientSecret == "" {
+		// The client ID is sent in the request body, as there is no secret
+		// to send with it in the "Authorization" header.
+		a.clientCredentials.AuthStyle = oauth2.AuthStyleInParams
+	}
+
+	client := &http.Client{
+		Timeout: cfg.Timeout,
+	}
+	tlsConfig, err := newTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		client.Transport = newTransport(tlsConfig)
+	}
+
+	// Each call to Token of the client credentials config requests a new
+	// token, the token source caches it and refreshes it before it expires.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+	a.tokenSource = newRefreshingTokenSource(ctx, a.clientCredentials.Token, cfg.RefreshMargin, cfg.Retry, logger)
+
+	return a, nil
+}
+
+// newTLSConfig returns the TLS config used to connect to the token endpoint,
+// or nil if the default one is enough.
+func newTLSConfig(settings TLSSettings) (*tls.Config, error) {
+	if settings.CAFile == "" && settings.CertFile == "" && settings.KeyFile == "" {
+		return nil, nil
+	}
+	if (settings.CertFile == "") != (settings.KeyFile == "") {
+		return nil, errIncompleteCertificate
+	}
+
+	tlsConfig := &tls.Config{}
+	if settings.CAFile != "" {
+		caPEM, err := ioutil.ReadFile(settings.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read \"tls.ca_file\": %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("\"tls.ca_file\" %q has no valid certificates", settings.CAFile)
+		}
+	}
+	if settings.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// newTransport returns a transport with the settings of http.DefaultTransport
+// and the given TLS config.
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+}
+
+// Start starts the background refresh of the token, the first one is
+// requested right away.
+func (a *clientCredentialsAuthenticator) Start(host component.Host) error {
+	a.tokenSource.start()
+	return nil
+}
+
+// Shutdown stops the background refresh of the token.
+func (a *clientCredentialsAuthenticator) Shutdown() error {
+	a.tokenSource.stop()
+	return nil
+}
+
+// RoundTripper returns a round tripper that sets the "Authorization" header
+// of the requests to the current token.
+func (a *clientCredentialsAuthenticator) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &oauth2.Transport{
+		Source: a.tokenSource,
+		Base:   base,
+	}, nil
+}
+
+// PerRPCCredentials returns gRPC credentials carrying the current token.
+func (a *clientCredentialsAuthenticator) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return grpcoauth.TokenSource{TokenSource: a.tokenSource}, nil
+}
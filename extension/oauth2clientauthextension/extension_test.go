@@ -0,0 +1,358 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+func newTokenServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(requests, 1)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "id", user)
+		assert.Equal(t, "secret", pass)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, "metrics traces", r.Form.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+}
+
+func newTestConfig(tokenURL string) *Config {
+	return &Config{
+		ClientID:      "id",
+		ClientSecret:  "secret",
+		TokenURL:      tokenURL,
+		Scopes:        []string{"metrics", "traces"},
+		Timeout:       time.Second,
+		RefreshMargin: time.Minute,
+		Retry: RetrySettings{
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     100 * time.Millisecond,
+		},
+	}
+}
+
+func newTestAuthenticator(t *testing.T, tokenURL string) *clientCredentialsAuthenticator {
+	return newTestAuthenticatorWithConfig(t, newTestConfig(tokenURL))
+}
+
+func newTestAuthenticatorWithConfig(t *testing.T, cfg *Config) *clientCredentialsAuthenticator {
+	a, err := newClientCredentialsAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, a.Start(component.NewMockHost()))
+	return a
+}
+
+func TestRoundTripper(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	var authorization []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+	}))
+	defer backend.Close()
+
+	a := newTestAuthenticator(t, tokenServer.URL)
+	defer a.Shutdown()
+
+	rt, err := a.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	client := &http.Client{Transport: rt}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(backend.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// The token is cached until it expires.
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-1"}, authorization)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	a := newTestAuthenticator(t, tokenServer.URL)
+	defer a.Shutdown()
+
+	creds, err := a.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token-1"}, md)
+}
+
+func TestTokenEndpointFailure(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer tokenServer.Close()
+
+	a := newTestAuthenticator(t, tokenServer.URL)
+	defer a.Shutdown()
+
+	rt, err := a.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	client := &http.Client{Transport: rt}
+
+	_, err = client.Get("http://localhost")
+	assert.Error(t, err)
+}
+
+func TestAudience(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "https://api.example.com", r.Form.Get("audience"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer tokenServer.Close()
+
+	cfg := newTestConfig(tokenServer.URL)
+	cfg.Audience = "https://api.example.com"
+	a := newTestAuthenticatorWithConfig(t, cfg)
+	defer a.Shutdown()
+
+	token, err := a.tokenSource.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+}
+
+func TestProactiveRefresh(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&tokenRequests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		// Tokens living less than twice the margin are refreshed at half
+		// their lifetime.
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":1}`, n)
+	}))
+	defer tokenServer.Close()
+
+	a := newTestAuthenticator(t, tokenServer.URL)
+	defer a.Shutdown()
+
+	// The token is replaced in the background, before it expires.
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&tokenRequests) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	token, err := a.tokenSource.Token()
+	require.NoError(t, err)
+	assert.NotEqual(t, "token-1", token.AccessToken)
+}
+
+func TestRefreshRetriesFailures(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&tokenRequests, 1) <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer tokenServer.Close()
+
+	a := newTestAuthenticator(t, tokenServer.URL)
+	defer a.Shutdown()
+
+	// The background loop keeps retrying until it gets a token.
+	assert.Eventually(t, func() bool {
+		a.tokenSource.mu.Lock()
+		defer a.tokenSource.mu.Unlock()
+		return a.tokenSource.isValid()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&tokenRequests))
+}
+
+func TestClientCertificate(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "oauth2client")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	certFile, keyFile := writeCertificate(t, tempDir)
+
+	tokenServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Len(t, r.TLS.PeerCertificates, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	tokenServer.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	tokenServer.StartTLS()
+	defer tokenServer.Close()
+
+	caFile := filepath.Join(tempDir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tokenServer.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caFile, caPEM, 0600))
+
+	cfg := newTestConfig(tokenServer.URL)
+	cfg.TLS = TLSSettings{CAFile: caFile}
+	a := newTestAuthenticatorWithConfig(t, cfg)
+	// Without a client certificate the handshake fails.
+	_, err = a.tokenSource.Token()
+	assert.Error(t, err)
+	a.Shutdown()
+
+	cfg.TLS.CertFile = certFile
+	cfg.TLS.KeyFile = keyFile
+	a = newTestAuthenticatorWithConfig(t, cfg)
+	defer a.Shutdown()
+	token, err := a.tokenSource.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+}
+
+func TestClientCertificateWithoutSecret(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "oauth2client")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	certFile, keyFile := writeCertificate(t, tempDir)
+
+	tokenServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Len(t, r.TLS.PeerCertificates, 1)
+		_, _, ok := r.BasicAuth()
+		assert.False(t, ok)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "id", r.Form.Get("client_id"))
+		assert.Empty(t, r.Form.Get("client_secret"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	tokenServer.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	tokenServer.StartTLS()
+	defer tokenServer.Close()
+
+	caFile := filepath.Join(tempDir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tokenServer.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caFile, caPEM, 0600))
+
+	// The client is authenticated by its certificate, see RFC 8705.
+	cfg := newTestConfig(tokenServer.URL)
+	cfg.ClientSecret = ""
+	cfg.TLS = TLSSettings{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}
+	a := newTestAuthenticatorWithConfig(t, cfg)
+	defer a.Shutdown()
+	token, err := a.tokenSource.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+}
+
+func TestTokenDuringRefresh(t *testing.T) {
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	var fetches int32
+	fetch := func(context.Context) (*oauth2.Token, error) {
+		n := atomic.AddInt32(&fetches, 1)
+		if n > 1 {
+			close(fetching)
+			<-release
+		}
+		return &oauth2.Token{
+			AccessToken: fmt.Sprintf("token-%d", n),
+			Expiry:      time.Now().Add(time.Hour),
+		}, nil
+	}
+	s := newRefreshingTokenSource(context.Background(), fetch, time.Minute, RetrySettings{
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     100 * time.Millisecond,
+	}, zap.NewNop())
+	defer s.stop()
+
+	token, err := s.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	// The token is due for a refresh, which blocks until released.
+	s.mu.Lock()
+	s.refreshAt = time.Now()
+	s.mu.Unlock()
+	refreshed := make(chan error, 1)
+	go func() {
+		refreshed <- s.refresh()
+	}()
+	<-fetching
+
+	// The current token is returned meanwhile.
+	token, err = s.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	close(release)
+	require.NoError(t, <-refreshed)
+	token, err = s.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken)
+}
+
+// writeCertificate writes a self-signed client certificate and its key to the
+// given directory.
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "otelcol"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
@@ -0,0 +1,85 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"errors"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/open-telemetry/opentelemetry-collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "oauth2client"
+
+	defaultTimeout              = 5 * time.Second
+	defaultRefreshMargin        = time.Minute
+	defaultRetryInitialInterval = time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+)
+
+var (
+	errNoClientIDProvided     = errors.New("\"client_id\" config cannot be empty")
+	errNoClientSecretProvided = errors.New("\"client_secret\" config cannot be empty without \"tls.cert_file\"")
+	errNoTokenURLProvided     = errors.New("\"token_url\" config cannot be empty")
+	errNegativeRefreshMargin  = errors.New("\"refresh_margin\" config cannot be negative")
+	errInvalidRetry           = errors.New("\"retry\" config must have a positive \"initial_interval\" not greater than \"max_interval\"")
+	errIncompleteCertificate  = errors.New("\"tls.cert_file\" and \"tls.key_file\" config must be set together")
+)
+
+// Factory is the factory for the OAuth2 client credentials extension.
+type Factory struct {
+}
+
+var _ extension.Factory = (*Factory)(nil)
+
+// Type gets the type of the config created by this factory.
+func (f *Factory) Type() string {
+	return typeStr
+}
+
+// CreateDefaultConfig creates the default configuration for the extension.
+func (f *Factory) CreateDefaultConfig() configmodels.Extension {
+	return &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Timeout:       defaultTimeout,
+		RefreshMargin: defaultRefreshMargin,
+		Retry: RetrySettings{
+			InitialInterval: defaultRetryInitialInterval,
+			MaxInterval:     defaultRetryMaxInterval,
+		},
+	}
+}
+
+// CreateExtension creates the extension based on this config.
+func (f *Factory) CreateExtension(
+	logger *zap.Logger,
+	cfg configmodels.Extension,
+) (extension.ServiceExtension, error) {
+	ext, err := newClientCredentialsAuthenticator(cfg.(*Config), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewClientAuthenticatorExtension(cfg.Name(), ext), nil
+}
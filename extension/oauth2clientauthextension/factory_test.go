@@ -0,0 +1,118 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configcheck"
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFactory_Type(t *testing.T) {
+	factory := Factory{}
+	require.Equal(t, typeStr, factory.Type())
+}
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				NameVal: typeStr,
+				TypeVal: typeStr,
+			},
+			Timeout:       defaultTimeout,
+			RefreshMargin: defaultRefreshMargin,
+			Retry: RetrySettings{
+				InitialInterval: defaultRetryInitialInterval,
+				MaxInterval:     defaultRetryMaxInterval,
+			},
+		},
+		cfg)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+	assert.Equal(t, errNoClientIDProvided, err)
+	assert.Nil(t, ext)
+
+	cfg.ClientID = "id"
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	assert.Equal(t, errNoClientSecretProvided, err)
+	assert.Nil(t, ext)
+
+	cfg.ClientSecret = "secret"
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	assert.Equal(t, errNoTokenURLProvided, err)
+	assert.Nil(t, ext)
+
+	cfg.TokenURL = "https://example.com/token"
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
+
+func TestFactory_CreateExtensionInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    error
+	}{
+		{
+			name:   "negative refresh margin",
+			modify: func(cfg *Config) { cfg.RefreshMargin = -time.Second },
+			err:    errNegativeRefreshMargin,
+		},
+		{
+			name:   "zero retry interval",
+			modify: func(cfg *Config) { cfg.Retry.InitialInterval = 0 },
+			err:    errInvalidRetry,
+		},
+		{
+			name:   "retry interval above max",
+			modify: func(cfg *Config) { cfg.Retry.InitialInterval = time.Hour },
+			err:    errInvalidRetry,
+		},
+		{
+			name:   "certificate without key",
+			modify: func(cfg *Config) { cfg.TLS.CertFile = "cert.pem" },
+			err:    errIncompleteCertificate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := Factory{}
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.ClientID = "id"
+			cfg.ClientSecret = "secret"
+			cfg.TokenURL = "https://example.com/token"
+			tt.modify(cfg)
+
+			ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+			assert.Equal(t, tt.err, err)
+			assert.Nil(t, ext)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension
+
+go 1.12
+
+require (
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth v0.0.0
+	github.com/stretchr/testify v1.5.1
+	go.uber.org/zap v1.13.0
+	golang.org/x/oauth2 v0.0.0-20191202225959-858c2ad4c8b6
+	google.golang.org/grpc v1.25.1
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../auth
@@ -0,0 +1,240 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// refreshingTokenSource caches the token of the client credentials flow. A
+// background loop requests a new token a margin before the current one
+// expires, retrying with exponential backoff on failures, so requests only
+// wait for the token endpoint when there is no valid token at all.
+type refreshingTokenSource struct {
+	logger *zap.Logger
+	fetch  func(context.Context) (*oauth2.Token, error)
+	margin time.Duration
+	retry  RetrySettings
+	now    func() time.Time
+
+	// ctx is used by the token requests, it is canceled on stop.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	token     *oauth2.Token
+	refreshAt time.Time
+	// pending is the token request in flight, nil if there is none.
+	pending *tokenRequest
+}
+
+// tokenRequest is shared by the callers needing a new token at the same time.
+type tokenRequest struct {
+	// done is closed once the request completed.
+	done chan struct{}
+	err  error
+}
+
+var _ oauth2.TokenSource = (*refreshingTokenSource)(nil)
+
+func newRefreshingTokenSource(
+	ctx context.Context,
+	fetch func(context.Context) (*oauth2.Token, error),
+	margin time.Duration,
+	retry RetrySettings,
+	logger *zap.Logger,
+) *refreshingTokenSource {
+	ctx, cancel := context.WithCancel(ctx)
+	return &refreshingTokenSource{
+		logger: logger,
+		fetch:  fetch,
+		margin: margin,
+		retry:  retry,
+		now:    time.Now,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Token returns the current token, requesting one if there is no valid token.
+// The current token is returned while a refresh is in flight.
+func (s *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	if s.isValid() {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+	s.mu.Unlock()
+
+	if err := s.fetchShared(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, nil
+}
+
+// start starts the background refresh of the token.
+func (s *refreshingTokenSource) start() {
+	s.wg.Add(1)
+	go s.refreshLoop()
+}
+
+// stop stops the background refresh and cancels the pending token requests.
+func (s *refreshingTokenSource) stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *refreshingTokenSource) refreshLoop() {
+	defer s.wg.Done()
+
+	backoff := s.retry.InitialInterval
+	for {
+		wait, ok := s.nextRefresh()
+		if !ok {
+			// The token never expires.
+			return
+		}
+		if !s.sleep(wait) {
+			return
+		}
+
+		if err := s.refresh(); err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			s.logger.Warn("Failed to refresh the OAuth2 token", zap.Error(err), zap.Duration("retry_in", backoff))
+			if !s.sleep(backoff) {
+				return
+			}
+			if backoff *= 2; backoff > s.retry.MaxInterval {
+				backoff = s.retry.MaxInterval
+			}
+			continue
+		}
+		backoff = s.retry.InitialInterval
+	}
+}
+
+// nextRefresh returns how long to wait before refreshing the token and false
+// if it doesn't need to be refreshed at all.
+func (s *refreshingTokenSource) nextRefresh() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isValid() {
+		return 0, true
+	}
+	if s.token.Expiry.IsZero() {
+		return 0, false
+	}
+	if wait := s.refreshAt.Sub(s.now()); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// refresh requests a new token unless the current one was just replaced, eg.:
+// by a call to Token.
+func (s *refreshingTokenSource) refresh() error {
+	s.mu.Lock()
+	upToDate := s.isValid() && (s.token.Expiry.IsZero() || s.now().Before(s.refreshAt))
+	s.mu.Unlock()
+	if upToDate {
+		return nil
+	}
+
+	// The current token, if still valid, is used while the new one is
+	// requested.
+	return s.fetchShared()
+}
+
+// fetchShared requests a new token, or waits for the request in flight if
+// there is one, so concurrent callers make a single request. The lock isn't
+// held during the request.
+func (s *refreshingTokenSource) fetchShared() error {
+	s.mu.Lock()
+	if req := s.pending; req != nil {
+		s.mu.Unlock()
+		<-req.done
+		return req.err
+	}
+	req := &tokenRequest{done: make(chan struct{})}
+	s.pending = req
+	s.mu.Unlock()
+
+	token, err := s.fetch(s.ctx)
+
+	s.mu.Lock()
+	if err == nil {
+		s.setToken(token)
+	}
+	s.pending = nil
+	s.mu.Unlock()
+
+	req.err = err
+	close(req.done)
+	return err
+}
+
+// setToken replaces the current token and schedules its refresh. It must be
+// called with the lock held.
+func (s *refreshingTokenSource) setToken(token *oauth2.Token) {
+	s.token = token
+	if token.Expiry.IsZero() {
+		return
+	}
+	now := s.now()
+	lifetime := token.Expiry.Sub(now)
+	wait := lifetime - s.margin
+	if wait < lifetime/2 {
+		wait = lifetime / 2
+	}
+	s.refreshAt = now.Add(wait)
+}
+
+// isValid returns whether there is a token that didn't expire. It must be
+// called with the lock held.
+func (s *refreshingTokenSource) isValid() bool {
+	if s.token == nil || s.token.AccessToken == "" {
+		return false
+	}
+	return s.token.Expiry.IsZero() || s.now().Before(s.token.Expiry)
+}
+
+// sleep waits for the given duration, it returns false if the token source
+// was stopped meanwhile.
+func (s *refreshingTokenSource) sleep(d time.Duration) bool {
+	if d <= 0 {
+		return s.ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
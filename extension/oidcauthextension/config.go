@@ -0,0 +1,45 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the OIDC authenticator extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Attribute is the name of the header, or gRPC metadata key, carrying
+	// the bearer token. The default value is "authorization".
+	Attribute string `mapstructure:"attribute"`
+
+	// IssuerURL is the base URL of the OIDC provider, its discovery document
+	// must be available under "/.well-known/openid-configuration". It must
+	// match the "iss" claim of the tokens. Has no default value.
+	IssuerURL string `mapstructure:"issuer_url"`
+
+	// Audience is the expected value of the "aud" claim of the tokens,
+	// typically the client ID of the application. Has no default value.
+	Audience string `mapstructure:"audience"`
+
+	// UsernameClaim is the claim holding the subject of the request. The
+	// default value is "sub".
+	UsernameClaim string `mapstructure:"username_claim"`
+
+	// GroupsClaim is the claim holding the groups the subject belongs to.
+	// Optional.
+	GroupsClaim string `mapstructure:"groups_claim"`
+}
@@ -0,0 +1,172 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-oidc"
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+type subjectKey struct{}
+type groupsKey struct{}
+
+// oidcAuthenticator validates the bearer tokens of incoming requests. The
+// signing keys of the provider are fetched on demand and cached by the
+// verifier.
+type oidcAuthenticator struct {
+	cfg      *Config
+	logger   *zap.Logger
+	verifier *oidc.IDTokenVerifier
+}
+
+var _ auth.ServerAuthenticator = (*oidcAuthenticator)(nil)
+
+func newOIDCAuthenticator(cfg *Config, logger *zap.Logger) (*oidcAuthenticator, error) {
+	if cfg.IssuerURL == "" {
+		return nil, errNoIssuerURL
+	}
+	if cfg.Audience == "" {
+		return nil, errNoAudience
+	}
+	if cfg.Attribute == "" {
+		cfg.Attribute = defaultAttribute
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = defaultUsernameClaim
+	}
+
+	return &oidcAuthenticator{
+		cfg:    cfg,
+		logger: logger,
+	}, nil
+}
+
+// Start fetches the discovery document of the provider.
+func (o *oidcAuthenticator) Start(host component.Host) error {
+	provider, err := oidc.NewProvider(context.Background(), o.cfg.IssuerURL)
+	if err != nil {
+		return fmt.Errorf("failed to get the configuration of the OIDC provider: %v", err)
+	}
+	o.verifier = provider.Verifier(&oidc.Config{
+		ClientID: o.cfg.Audience,
+	})
+	return nil
+}
+
+// Shutdown is a no-op.
+func (o *oidcAuthenticator) Shutdown() error {
+	return nil
+}
+
+// Authenticate verifies the signature, issuer, audience and expiration of
+// the bearer token found in the headers. The returned context carries the
+// subject and groups of the token, see SubjectFromContext and
+// GroupsFromContext.
+func (o *oidcAuthenticator) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	raw, err := o.bearerToken(headers)
+	if err != nil {
+		return ctx, err
+	}
+
+	idToken, err := o.verifier.Verify(ctx, raw)
+	if err != nil {
+		o.logger.Debug("Failed to verify token", zap.Error(err))
+		return ctx, fmt.Errorf("%v: %v", auth.ErrNotAuthenticated, err)
+	}
+
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return ctx, fmt.Errorf("%v: failed to parse the claims: %v", auth.ErrNotAuthenticated, err)
+	}
+
+	subject, ok := claims[o.cfg.UsernameClaim].(string)
+	if !ok || subject == "" {
+		return ctx, fmt.Errorf("%v: claim %q not found in the token", auth.ErrNotAuthenticated, o.cfg.UsernameClaim)
+	}
+	authCtx := context.WithValue(ctx, subjectKey{}, subject)
+
+	if o.cfg.GroupsClaim != "" {
+		groups, err := parseGroups(claims[o.cfg.GroupsClaim])
+		if err != nil {
+			return ctx, fmt.Errorf("%v: %v", auth.ErrNotAuthenticated, err)
+		}
+		authCtx = context.WithValue(authCtx, groupsKey{}, groups)
+	}
+
+	return authCtx, nil
+}
+
+// bearerToken returns the token of the configured attribute. Headers are
+// looked up ignoring the case since HTTP canonicalizes them while gRPC
+// lowercases them.
+func (o *oidcAuthenticator) bearerToken(headers map[string][]string) (string, error) {
+	var values []string
+	for k, v := range headers {
+		if strings.EqualFold(k, o.cfg.Attribute) {
+			values = v
+			break
+		}
+	}
+	if len(values) == 0 {
+		return "", auth.ErrNotAuthenticated
+	}
+
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return "", fmt.Errorf("%v: expected a bearer token", auth.ErrNotAuthenticated)
+	}
+	return parts[1], nil
+}
+
+func parseGroups(claim interface{}) ([]string, error) {
+	switch v := claim.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, g := range v {
+			s, ok := g.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type %T in groups claim", g)
+			}
+			groups = append(groups, s)
+		}
+		return groups, nil
+	}
+	return nil, fmt.Errorf("unexpected type %T for groups claim", claim)
+}
+
+// SubjectFromContext returns the subject of the token authenticated by the
+// extension, if any.
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(subjectKey{}).(string)
+	return subject, ok
+}
+
+// GroupsFromContext returns the groups of the token authenticated by the
+// extension, if any.
+func GroupsFromContext(ctx context.Context) ([]string, bool) {
+	groups, ok := ctx.Value(groupsKey{}).([]string)
+	return groups, ok
+}
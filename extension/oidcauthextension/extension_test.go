@@ -0,0 +1,191 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// oidcServer is a minimal OIDC provider serving the discovery document and
+// the signing key used to sign the test tokens.
+type oidcServer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newOIDCServer(t *testing.T) *oidcServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s := &oidcServer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                s.URL,
+			"jwks_uri":                              s.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]interface{}{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *oidcServer) token(t *testing.T, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (s *oidcServer) claims(audience string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":    s.URL,
+		"aud":    audience,
+		"sub":    "jdoe",
+		"groups": []string{"dev", "ops"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
+	}
+}
+
+func newTestAuthenticator(t *testing.T, issuerURL string) *oidcAuthenticator {
+	o, err := newOIDCAuthenticator(&Config{
+		IssuerURL:   issuerURL,
+		Audience:    "otel-collector",
+		GroupsClaim: "groups",
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, o.Start(component.NewMockHost()))
+	return o
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := newOIDCServer(t)
+	defer server.Close()
+
+	o := newTestAuthenticator(t, server.URL)
+	defer o.Shutdown()
+
+	token := server.token(t, server.claims("otel-collector"))
+	ctx, err := o.Authenticate(context.Background(), map[string][]string{
+		"authorization": {"Bearer " + token},
+	})
+	require.NoError(t, err)
+
+	subject, ok := SubjectFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "jdoe", subject)
+
+	groups, ok := GroupsFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"dev", "ops"}, groups)
+}
+
+func TestAuthenticateFailures(t *testing.T) {
+	server := newOIDCServer(t)
+	defer server.Close()
+
+	o := newTestAuthenticator(t, server.URL)
+	defer o.Shutdown()
+
+	expired := server.claims("otel-collector")
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	noSubject := server.claims("otel-collector")
+	delete(noSubject, "sub")
+
+	tests := []struct {
+		name    string
+		headers map[string][]string
+	}{
+		{
+			name: "no_header",
+		},
+		{
+			name:    "not_bearer",
+			headers: map[string][]string{"Authorization": {"Basic dXNlcjpwYXNz"}},
+		},
+		{
+			name:    "invalid_token",
+			headers: map[string][]string{"Authorization": {"Bearer invalid"}},
+		},
+		{
+			name:    "wrong_audience",
+			headers: map[string][]string{"Authorization": {"Bearer " + server.token(t, server.claims("other"))}},
+		},
+		{
+			name:    "expired",
+			headers: map[string][]string{"Authorization": {"Bearer " + server.token(t, expired)}},
+		},
+		{
+			name:    "no_subject",
+			headers: map[string][]string{"Authorization": {"Bearer " + server.token(t, noSubject)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := o.Authenticate(context.Background(), tt.headers)
+			assert.Error(t, err)
+			_, ok := SubjectFromContext(ctx)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestStartFailsWithoutProvider(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	o, err := newOIDCAuthenticator(&Config{
+		IssuerURL: server.URL,
+		Audience:  "otel-collector",
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, o.Start(component.NewMockHost()))
+}
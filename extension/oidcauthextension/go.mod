@@ -0,0 +1,15 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension
+
+go 1.12
+
+require (
+	github.com/coreos/go-oidc v2.2.1+incompatible
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth v0.0.0
+	github.com/pquerna/cachecontrol v0.0.0-20180517163645-1555304b9b35 // indirect
+	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.13.0
+	gopkg.in/square/go-jose.v2 v2.5.1 // indirect
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../auth
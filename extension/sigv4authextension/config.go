@@ -0,0 +1,48 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigv4authextension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the SigV4 auth extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Region is the AWS region the requests are signed for, eg.: "us-east-1".
+	// Has no default value.
+	Region string `mapstructure:"region"`
+
+	// Service is the signing name of the AWS service receiving the requests,
+	// eg.: "aps" for Amazon Managed Service for Prometheus or "es" for Amazon
+	// OpenSearch Service. Has no default value.
+	Service string `mapstructure:"service"`
+
+	// AssumeRole holds the settings of the role to assume, optional. When
+	// not set the credentials are taken from the default chain: environment,
+	// shared credentials file, EC2 instance or ECS task role.
+	AssumeRole AssumeRole `mapstructure:"assume_role"`
+}
+
+// AssumeRole defines the role assumed to sign the requests.
+type AssumeRole struct {
+	// ARN is the Amazon Resource Name of the role.
+	ARN string `mapstructure:"arn"`
+
+	// SessionName is the name of the session, it appears in CloudTrail logs.
+	// The default is generated by the AWS SDK.
+	SessionName string `mapstructure:"session_name"`
+}
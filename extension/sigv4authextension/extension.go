@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigv4authextension
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"go.uber.org/zap"
+	grpccredentials "google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+)
+
+var errGRPCNotSupported = errors.New("sigv4auth doesn't support gRPC, only HTTP exporters can use it")
+
+// sigv4Auth signs outgoing HTTP requests with AWS Signature Version 4.
+type sigv4Auth struct {
+	cfg    *Config
+	logger *zap.Logger
+	signer *v4.Signer
+}
+
+var _ auth.ClientAuthenticator = (*sigv4Auth)(nil)
+
+func newSigv4Auth(cfg *Config, logger *zap.Logger) (*sigv4Auth, error) {
+	if cfg.Region == "" {
+		return nil, errNoRegion
+	}
+	if cfg.Service == "" {
+		return nil, errNoService
+	}
+	return &sigv4Auth{
+		cfg:    cfg,
+		logger: logger,
+	}, nil
+}
+
+// Start resolves the credentials used to sign the requests.
+func (s *sigv4Auth) Start(host component.Host) error {
+	sessionCfg := awsutil.CreateDefaultSessionConfig()
+	sessionCfg.Region = s.cfg.Region
+	sessionCfg.RoleARN = s.cfg.AssumeRole.ARN
+
+	conn := &awsutil.Conn{RoleSessionName: s.cfg.AssumeRole.SessionName}
+	_, sess, err := awsutil.GetAWSConfigSession(s.logger, conn, &sessionCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create the AWS session: %v", err)
+	}
+	s.signer = v4.NewSigner(sess.Config.Credentials)
+	return nil
+}
+
+// Shutdown is a no-op.
+func (s *sigv4Auth) Shutdown() error {
+	return nil
+}
+
+// RoundTripper returns a round tripper signing the requests.
+func (s *sigv4Auth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &signingRoundTripper{
+		base: base,
+		auth: s,
+	}, nil
+}
+
+// PerRPCCredentials isn't supported, SigV4 signs the whole HTTP request
+// which isn't available to gRPC credentials.
+func (s *sigv4Auth) PerRPCCredentials() (grpccredentials.PerRPCCredentials, error) {
+	return nil, errGRPCNotSupported
+}
+
+type signingRoundTripper struct {
+	base http.RoundTripper
+	auth *sigv4Auth
+}
+
+func (r *signingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The payload is part of the signature, it needs to be read upfront.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the request body: %v", err)
+		}
+	}
+
+	// Round trippers must not modify the original request, the signer
+	// attaches the body it signed to the new one.
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.Header = make(http.Header, len(req.Header)+4)
+	for k, v := range req.Header {
+		newReq.Header[k] = v
+	}
+
+	_, err := r.auth.signer.Sign(newReq, bytes.NewReader(body), r.auth.cfg.Service, r.auth.cfg.Region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the request: %v", err)
+	}
+	return r.base.RoundTrip(newReq)
+}
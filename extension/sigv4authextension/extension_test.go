@@ -0,0 +1,92 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigv4authextension
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setEnv(t *testing.T, key, value string) func() {
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	return func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestRoundTripperSignsRequests(t *testing.T) {
+	defer setEnv(t, "AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")()
+	defer setEnv(t, "AWS_SECRET_ACCESS_KEY", "secret")()
+
+	var authorization, amzDate string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		amzDate = r.Header.Get("X-Amz-Date")
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	s, err := newSigv4Auth(&Config{
+		Region:  "us-east-1",
+		Service: "aps",
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, s.Start(component.NewMockHost()))
+	defer s.Shutdown()
+
+	rt, err := s.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/remote_write", bytes.NewReader([]byte("payload")))
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), authorization)
+	assert.Contains(t, authorization, "/us-east-1/aps/aws4_request")
+	assert.NotEmpty(t, amzDate)
+	assert.Equal(t, []byte("payload"), body)
+	// The original request must not be modified.
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestPerRPCCredentialsNotSupported(t *testing.T) {
+	s, err := newSigv4Auth(&Config{
+		Region:  "us-east-1",
+		Service: "aps",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	creds, err := s.PerRPCCredentials()
+	assert.Equal(t, errGRPCNotSupported, err)
+	assert.Nil(t, creds)
+}
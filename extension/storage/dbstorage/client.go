@@ -0,0 +1,68 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+)
+
+// dbStorageClient implements storage.Client on top of a database table.
+type dbStorageClient struct {
+	db         *sql.DB
+	statements dialect
+}
+
+func newDBStorageClient(ctx context.Context, db *sql.DB, statements dialect) (*dbStorageClient, error) {
+	if _, err := db.ExecContext(ctx, statements.createTable); err != nil {
+		return nil, err
+	}
+
+	return &dbStorageClient{
+		db:         db,
+		statements: statements,
+	}, nil
+}
+
+// Get returns the value stored for the key or nil if there is none.
+func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := c.db.QueryRowContext(ctx, c.statements.get, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set stores the value for the key.
+func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	_, err := c.db.ExecContext(ctx, c.statements.set, key, value)
+	return err
+}
+
+// Delete removes the key and its value.
+func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
+	_, err := c.db.ExecContext(ctx, c.statements.delete, key)
+	return err
+}
+
+// Close does nothing, the database is shared by all the clients and closed
+// when the extension shuts down.
+func (c *dbStorageClient) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	driverSQLite   = "sqlite"
+	driverPostgres = "postgres"
+)
+
+// dialect holds the statements used by the client, they differ slightly
+// between databases: types and placeholders. The "%s" is replaced by the
+// quoted name of the table of the component.
+type dialect struct {
+	createTable string
+	get         string
+	set         string
+	delete      string
+}
+
+var dialects = map[string]dialect{
+	driverSQLite: {
+		createTable: "CREATE TABLE IF NOT EXISTS %s (key TEXT PRIMARY KEY, value BLOB)",
+		get:         "SELECT value FROM %s WHERE key = ?",
+		set:         "INSERT INTO %s (key, value) VALUES (?, ?) ON CONFLICT (key) DO UPDATE SET value = excluded.value",
+		delete:      "DELETE FROM %s WHERE key = ?",
+	},
+	driverPostgres: {
+		createTable: "CREATE TABLE IF NOT EXISTS %s (key TEXT PRIMARY KEY, value BYTEA)",
+		get:         "SELECT value FROM %s WHERE key = $1",
+		set:         "INSERT INTO %s (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value",
+		delete:      "DELETE FROM %s WHERE key = $1",
+	},
+}
+
+// quoteIdentifier quotes the name so it can be used as a table name even if
+// it contains characters like "/", both databases support the standard SQL
+// double quotes.
+func quoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (d dialect) forTable(table string) dialect {
+	quoted := quoteIdentifier(table)
+	return dialect{
+		createTable: fmt.Sprintf(d.createTable, quoted),
+		get:         fmt.Sprintf(d.get, quoted),
+		set:         fmt.Sprintf(d.set, quoted),
+		delete:      fmt.Sprintf(d.delete, quoted),
+	}
+}
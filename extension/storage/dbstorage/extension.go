@@ -0,0 +1,106 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	// Register the supported database drivers.
+	_ "github.com/lib/pq"
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+// databaseStorage implements storage.Extension keeping the data of each
+// component on its own table of a SQL database.
+type databaseStorage struct {
+	logger     *zap.Logger
+	driverName string
+	dataSource string
+	dialect    dialect
+	db         *sql.DB
+}
+
+var _ storage.Extension = (*databaseStorage)(nil)
+
+func newDBStorage(config *Config, logger *zap.Logger) (*databaseStorage, error) {
+	d, ok := dialects[config.DriverName]
+	if !ok {
+		return nil, fmt.Errorf(
+			"%q unsupported \"driver\" %q, must be %q or %q",
+			config.Name(),
+			config.DriverName,
+			driverSQLite,
+			driverPostgres)
+	}
+
+	if config.DataSource == "" {
+		return nil, fmt.Errorf("%q config requires a non-empty \"datasource\"", config.Name())
+	}
+
+	return &databaseStorage{
+		logger:     logger,
+		driverName: config.DriverName,
+		dataSource: config.DataSource,
+		dialect:    d,
+	}, nil
+}
+
+// Start opens the database and checks that it is reachable.
+func (ds *databaseStorage) Start(host component.Host) error {
+	db, err := sql.Open(ds.driverName, ds.dataSource)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return err
+	}
+
+	ds.db = db
+	return nil
+}
+
+// Shutdown closes the database, the clients can't be used after it.
+func (ds *databaseStorage) Shutdown() error {
+	if ds.db == nil {
+		return nil
+	}
+	return ds.db.Close()
+}
+
+// GetClient returns a storage client backed by a table dedicated to the
+// given component, the table is created if it doesn't exist.
+func (ds *databaseStorage) GetClient(ctx context.Context, kind storage.Kind, name string) (storage.Client, error) {
+	if ds.db == nil {
+		return nil, errors.New("database storage is not started")
+	}
+
+	table := fmt.Sprintf("%s_%s", kind, name)
+	client, err := newDBStorageClient(ctx, ds.db, ds.dialect.forTable(table))
+	if err != nil {
+		return nil, err
+	}
+
+	ds.logger.Debug("Created storage client", zap.String("table", table))
+	return client, nil
+}
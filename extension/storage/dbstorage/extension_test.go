@@ -0,0 +1,94 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+func TestExtensionWithSQLite(t *testing.T) {
+	ctx := context.Background()
+	tempDir, err := ioutil.TempDir("", "db_storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ext, err := newDBStorage(&Config{
+		DriverName: driverSQLite,
+		DataSource: filepath.Join(tempDir, "storage.db"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = ext.GetClient(ctx, storage.KindReceiver, "filelog")
+	assert.Error(t, err, "clients can't be created before start")
+
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown())
+	}()
+
+	client1, err := ext.GetClient(ctx, storage.KindReceiver, "filelog/1")
+	require.NoError(t, err)
+	defer client1.Close(ctx)
+	client2, err := ext.GetClient(ctx, storage.KindExporter, "filelog/1")
+	require.NoError(t, err)
+	defer client2.Close(ctx)
+
+	value, err := client1.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client1.Set(ctx, "key", []byte("value1")))
+	require.NoError(t, client2.Set(ctx, "key", []byte("value2")))
+	// Overwriting is not an error.
+	require.NoError(t, client1.Set(ctx, "key", []byte("new value1")))
+
+	value, err = client1.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value1"), value)
+
+	value, err = client2.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value2"), value)
+
+	require.NoError(t, client1.Delete(ctx, "key"))
+	value, err = client1.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// Deleting a missing key is not an error.
+	require.NoError(t, client1.Delete(ctx, "key"))
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, `"receiver_filelog/1"`, quoteIdentifier("receiver_filelog/1"))
+	assert.Equal(t, `"exporter_a""b"`, quoteIdentifier(`exporter_a"b`))
+}
+
+func TestDialectForTable(t *testing.T) {
+	d := dialects[driverPostgres].forTable("receiver_filelog")
+	assert.Equal(t, `SELECT value FROM "receiver_filelog" WHERE key = $1`, d.get)
+	assert.Equal(t, `DELETE FROM "receiver_filelog" WHERE key = $1`, d.delete)
+}
@@ -0,0 +1,52 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configcheck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := Factory{}
+	assert.Equal(t, typeStr, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	// Driver and datasource have no default values.
+	ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+	assert.EqualError(t, err, "\"db_storage\" unsupported \"driver\" \"\", must be \"sqlite\" or \"postgres\"")
+	assert.Nil(t, ext)
+
+	cfg.DriverName = driverPostgres
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	assert.EqualError(t, err, "\"db_storage\" config requires a non-empty \"datasource\"")
+	assert.Nil(t, ext)
+
+	cfg.DataSource = "postgres://localhost/otelcol"
+	ext, err = factory.CreateExtension(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
@@ -0,0 +1,14 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage
+
+go 1.15
+
+require (
+	github.com/lib/pq v1.9.0
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.0.0
+	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.13.0
+	modernc.org/sqlite v1.7.4
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ../
@@ -0,0 +1,468 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+// compactionSuffix is appended to the name of the temporary files created
+// while compacting. It can't be produced by sanitize, so it never clashes
+// with the file of another component.
+const compactionSuffix = "~compact"
+
+var (
+	defaultBucket = []byte(`default`)
+	// expiryBucket holds the expiration time, in Unix nanoseconds, of the
+	// keys written while a TTL is configured.
+	expiryBucket = []byte(`expiry`)
+
+	errNoBucket = errors.New("storage file is not initialized")
+)
+
+// clientSettings holds the settings shared by all the clients of an
+// extension.
+type clientSettings struct {
+	timeout            time.Duration
+	fsync              bool
+	maxSize            int64
+	ttl                time.Duration
+	compactOnStart     bool
+	compactionInterval time.Duration
+}
+
+// fileStorageClient implements storage.Client on top of a bbolt file.
+type fileStorageClient struct {
+	// nextPurge is when, in Unix nanoseconds, the next write removes the
+	// expired keys. It is first to be 64-bit aligned for atomic operations.
+	nextPurge int64
+
+	logger   *zap.Logger
+	filePath string
+	options  *bbolt.Options
+	maxSize  int64
+	ttl      time.Duration
+	now      func() time.Time
+
+	// mu protects db, which is replaced when the file is compacted.
+	mu sync.RWMutex
+	db *bbolt.DB
+
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newFileStorageClient(filePath string, settings clientSettings, logger *zap.Logger) (*fileStorageClient, error) {
+	options := &bbolt.Options{
+		Timeout: settings.timeout,
+		NoSync:  !settings.fsync,
+	}
+	db, err := bbolt.Open(filePath, 0600, options)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &fileStorageClient{
+		logger:   logger,
+		filePath: filePath,
+		options:  options,
+		maxSize:  settings.maxSize,
+		ttl:      settings.ttl,
+		now:      time.Now,
+		db:       db,
+		done:     make(chan struct{}),
+	}
+
+	if err := db.Update(c.initBuckets); err != nil {
+		db.Close()
+		return nil, err
+	}
+	c.nextPurge = c.now().Add(c.ttl).UnixNano()
+
+	// The lock of the file is held now, so leftovers of a compaction
+	// interrupted by a crash can't be in use.
+	c.removeCompactionLeftovers()
+
+	if settings.compactOnStart {
+		if err := c.compact(); err != nil {
+			c.db.Close()
+			return nil, err
+		}
+	}
+
+	if settings.compactionInterval > 0 {
+		c.wg.Add(1)
+		go c.compactPeriodically(settings.compactionInterval)
+	}
+
+	return c, nil
+}
+
+// initBuckets creates the buckets if needed. When a TTL is configured it also
+// removes the expired keys and sets the expiration of the keys written while
+// there was none, so they don't live forever.
+func (c *fileStorageClient) initBuckets(tx *bbolt.Tx) error {
+	bucket, err := tx.CreateBucketIfNotExists(defaultBucket)
+	if err != nil {
+		return err
+	}
+	expiry, err := tx.CreateBucketIfNotExists(expiryBucket)
+	if err != nil {
+		return err
+	}
+	if c.ttl == 0 {
+		return nil
+	}
+
+	now := c.now()
+	err = bucket.ForEach(func(key, _ []byte) error {
+		if expiry.Get(key) == nil {
+			return expiry.Put(key, expiryValue(now.Add(c.ttl)))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = deleteExpired(tx, now)
+	return err
+}
+
+// Get returns the value stored for the key or nil if there is none or it
+// expired.
+func (c *fileStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []byte
+	get := func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		expiry := tx.Bucket(expiryBucket)
+		if bucket == nil || expiry == nil {
+			return errNoBucket
+		}
+		if c.ttl > 0 {
+			if exp := expiry.Get([]byte(key)); exp != nil && isExpired(exp, c.now()) {
+				return nil
+			}
+		}
+		// The value is only valid during the transaction, copy it.
+		if value := bucket.Get([]byte(key)); value != nil {
+			result = append([]byte{}, value...)
+		}
+		return nil
+	}
+
+	if err := c.db.View(get); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Set stores the value for the key. It returns storage.ErrStorageFull if the
+// data in the file would exceed the maximum size. When a TTL is configured the
+// expired keys are removed first, at most once per TTL, so their space is
+// reused and the file doesn't grow without bound.
+func (c *fileStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.ttl > 0 {
+		if now := c.now(); now.UnixNano() >= atomic.LoadInt64(&c.nextPurge) {
+			if _, err := c.purgeExpired(now); err != nil {
+				return err
+			}
+		}
+	}
+
+	set := func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		expiry := tx.Bucket(expiryBucket)
+		if bucket == nil || expiry == nil {
+			return errNoBucket
+		}
+		if c.maxSize > 0 && c.usedSize(tx)+int64(len(key)+len(value)) > c.maxSize {
+			return storage.ErrStorageFull
+		}
+		if err := bucket.Put([]byte(key), value); err != nil {
+			return err
+		}
+		if c.ttl > 0 {
+			return expiry.Put([]byte(key), expiryValue(c.now().Add(c.ttl)))
+		}
+		return expiry.Delete([]byte(key))
+	}
+
+	err := c.db.Update(set)
+	if err == storage.ErrStorageFull && c.ttl > 0 {
+		// The keys that expired since the last removal take space too,
+		// remove them and try again.
+		deleted, purgeErr := c.purgeExpired(c.now())
+		if purgeErr != nil {
+			return purgeErr
+		}
+		if deleted > 0 {
+			err = c.db.Update(set)
+		}
+	}
+	return err
+}
+
+// purgeExpired removes the expired keys in their own transaction, the pages
+// they free are only counted once the removal is committed. It returns how
+// many keys were removed.
+func (c *fileStorageClient) purgeExpired(now time.Time) (int, error) {
+	var deleted int
+	err := c.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		deleted, err = deleteExpired(tx, now)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	atomic.StoreInt64(&c.nextPurge, now.Add(c.ttl).UnixNano())
+	return deleted, nil
+}
+
+// Delete removes the key and its value.
+func (c *fileStorageClient) Delete(_ context.Context, key string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return deleteKey(tx, []byte(key))
+	})
+}
+
+// Close stops the compaction, if any, and closes the underlying file. It can
+// be called more than once.
+func (c *fileStorageClient) Close(_ context.Context) error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.wg.Wait()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.closeErr = c.db.Close()
+	})
+	return c.closeErr
+}
+
+// usedSize returns the number of bytes of the file that hold data. The pages
+// freed by deleted keys are reused by later writes, so they are not counted.
+func (c *fileStorageClient) usedSize(tx *bbolt.Tx) int64 {
+	stats := c.db.Stats()
+	freePages := stats.FreePageN + stats.PendingPageN
+	return tx.Size() - int64(freePages)*int64(c.db.Info().PageSize)
+}
+
+// compact rewrites the file keeping only the keys that didn't expire. The file
+// never shrinks otherwise, the pages freed by deleted keys are only reused.
+// Clients are blocked while it runs.
+func (c *fileStorageClient) compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dir, name := filepath.Split(c.filePath)
+	tempFile, err := ioutil.TempFile(dir, name+compactionSuffix)
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	tempFile.Close()
+
+	if err := c.copyTo(tempPath); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	if err := c.db.Close(); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	renameErr := os.Rename(tempPath, c.filePath)
+	if renameErr != nil {
+		os.Remove(tempPath)
+	} else {
+		// Persist the rename, or the old file could come back after a crash.
+		renameErr = syncDir(filepath.Dir(c.filePath))
+	}
+
+	// Reopen the file, compacted or not. If that fails the closed db is kept,
+	// so the operations of the client return an error instead of panicking.
+	db, err := bbolt.Open(c.filePath, 0600, c.options)
+	if err != nil {
+		return err
+	}
+	c.db = db
+	return renameErr
+}
+
+// copyTo writes the keys that didn't expire to a new file at the given path.
+// The new file is always synced, whatever the fsync setting, as it replaces
+// the current one.
+func (c *fileStorageClient) copyTo(path string) error {
+	options := *c.options
+	options.NoSync = false
+	dst, err := bbolt.Open(path, 0600, &options)
+	if err != nil {
+		return err
+	}
+
+	now := c.now()
+	copyKeys := func(src *bbolt.Tx) error {
+		srcBucket := src.Bucket(defaultBucket)
+		srcExpiry := src.Bucket(expiryBucket)
+		if srcBucket == nil || srcExpiry == nil {
+			return errNoBucket
+		}
+		return dst.Update(func(tx *bbolt.Tx) error {
+			bucket, err := tx.CreateBucket(defaultBucket)
+			if err != nil {
+				return err
+			}
+			expiry, err := tx.CreateBucket(expiryBucket)
+			if err != nil {
+				return err
+			}
+			return srcBucket.ForEach(func(key, value []byte) error {
+				exp := srcExpiry.Get(key)
+				if exp != nil && c.ttl > 0 && isExpired(exp, now) {
+					return nil
+				}
+				if err := bucket.Put(key, value); err != nil {
+					return err
+				}
+				if exp == nil {
+					return nil
+				}
+				return expiry.Put(key, exp)
+			})
+		})
+	}
+
+	if err := c.db.View(copyKeys); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func (c *fileStorageClient) compactPeriodically(interval time.Duration) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.compact(); err != nil {
+				c.logger.Warn("Failed to compact storage file", zap.String("file", c.filePath), zap.Error(err))
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *fileStorageClient) removeCompactionLeftovers() {
+	leftovers, err := filepath.Glob(c.filePath + compactionSuffix + "*")
+	if err != nil {
+		return
+	}
+	for _, leftover := range leftovers {
+		if err := os.Remove(leftover); err != nil {
+			c.logger.Warn("Failed to remove compaction leftover", zap.String("file", leftover), zap.Error(err))
+		}
+	}
+}
+
+// deleteExpired removes the expired keys and returns how many were removed.
+func deleteExpired(tx *bbolt.Tx, now time.Time) (int, error) {
+	expiry := tx.Bucket(expiryBucket)
+	if expiry == nil {
+		return 0, errNoBucket
+	}
+
+	var expired [][]byte
+	err := expiry.ForEach(func(key, exp []byte) error {
+		if isExpired(exp, now) {
+			// Keys can't be removed while iterating, and are only valid
+			// until then, copy them.
+			expired = append(expired, append([]byte{}, key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range expired {
+		if err := deleteKey(tx, key); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
+
+func deleteKey(tx *bbolt.Tx, key []byte) error {
+	bucket := tx.Bucket(defaultBucket)
+	expiry := tx.Bucket(expiryBucket)
+	if bucket == nil || expiry == nil {
+		return errNoBucket
+	}
+	if err := bucket.Delete(key); err != nil {
+		return err
+	}
+	return expiry.Delete(key)
+}
+
+func expiryValue(t time.Time) []byte {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(t.UnixNano()))
+	return value
+}
+
+func isExpired(value []byte, now time.Time) bool {
+	return len(value) == 8 && int64(binary.BigEndian.Uint64(value)) <= now.UnixNano()
+}
@@ -0,0 +1,290 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+func TestClientOperations(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClient(t)
+	defer os.RemoveAll(tempDir)
+	defer client.Close(ctx)
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// Overwriting is not an error.
+	require.NoError(t, client.Set(ctx, "key", []byte("new value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value"), value)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// Deleting a missing key is not an error.
+	require.NoError(t, client.Delete(ctx, "key"))
+}
+
+func TestClientLockedFile(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClient(t)
+	defer os.RemoveAll(tempDir)
+	defer client.Close(ctx)
+
+	// The file is locked by the first client, a second one must time out.
+	_, err := newFileStorageClient(filepath.Join(tempDir, "test"), clientSettings{timeout: 10 * time.Millisecond}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestClientMaxSize(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClientWithSettings(t, clientSettings{timeout: time.Second, maxSize: 1024 * 1024})
+	defer os.RemoveAll(tempDir)
+	defer client.Close(ctx)
+
+	value := make([]byte, 100*1024)
+	var err error
+	keys := 0
+	for ; keys < 20; keys++ {
+		if err = client.Set(ctx, strconv.Itoa(keys), value); err != nil {
+			break
+		}
+	}
+	assert.Equal(t, storage.ErrStorageFull, err)
+	assert.True(t, keys > 0)
+
+	// The space of deleted keys can be used again.
+	require.NoError(t, client.Delete(ctx, "0"))
+	require.NoError(t, client.Set(ctx, "0", value))
+}
+
+func TestClientMaxSizeWithExpiredKeys(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClientWithSettings(t, clientSettings{timeout: time.Second, maxSize: 1024 * 1024, ttl: time.Minute})
+	defer os.RemoveAll(tempDir)
+	defer client.Close(ctx)
+
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	value := make([]byte, 100*1024)
+	var err error
+	for keys := 0; keys < 20; keys++ {
+		if err = client.Set(ctx, strconv.Itoa(keys), value); err != nil {
+			break
+		}
+	}
+	require.Equal(t, storage.ErrStorageFull, err)
+
+	// The space of expired keys can be used again.
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, client.Set(ctx, "new", value))
+}
+
+func TestClientTTL(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClientWithSettings(t, clientSettings{timeout: time.Second, ttl: time.Minute})
+	defer os.RemoveAll(tempDir)
+	defer client.Close(ctx)
+
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	require.NoError(t, client.Set(ctx, "old", []byte("old value")))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, client.Set(ctx, "new", []byte("new value")))
+
+	now = now.Add(45 * time.Second)
+	value, err := client.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	value, err = client.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value"), value)
+
+	// Expired keys are removed from the file on compaction.
+	require.NoError(t, client.compact())
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		assert.Equal(t, 1, tx.Bucket(defaultBucket).Stats().KeyN)
+		assert.Equal(t, 1, tx.Bucket(expiryBucket).Stats().KeyN)
+		return nil
+	}))
+}
+
+func TestClientTTLRemovesExpiredKeysOnWrite(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClientWithSettings(t, clientSettings{timeout: time.Second, ttl: time.Minute})
+	defer os.RemoveAll(tempDir)
+	defer client.Close(ctx)
+
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	keyCount := func() int {
+		var keys int
+		require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+			keys = tx.Bucket(defaultBucket).Stats().KeyN
+			assert.Equal(t, keys, tx.Bucket(expiryBucket).Stats().KeyN)
+			return nil
+		}))
+		return keys
+	}
+
+	require.NoError(t, client.Set(ctx, "k0", []byte("v0")))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, client.Set(ctx, "k1", []byte("v1")))
+	assert.Equal(t, 2, keyCount())
+
+	// k0 expired, the next write removes it.
+	now = now.Add(30 * time.Second)
+	require.NoError(t, client.Set(ctx, "k2", []byte("v2")))
+	assert.Equal(t, 2, keyCount())
+
+	// k1 expired too, but the expired keys are removed at most once per TTL.
+	now = now.Add(45 * time.Second)
+	require.NoError(t, client.Set(ctx, "k3", []byte("v3")))
+	assert.Equal(t, 3, keyCount())
+
+	now = now.Add(15 * time.Second)
+	require.NoError(t, client.Set(ctx, "k4", []byte("v4")))
+	assert.Equal(t, 2, keyCount())
+}
+
+func TestClientCompaction(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClient(t)
+	defer os.RemoveAll(tempDir)
+	defer client.Close(ctx)
+
+	value := make([]byte, 10*1024)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, client.Set(ctx, strconv.Itoa(i), value))
+	}
+	for i := 1; i < 100; i++ {
+		require.NoError(t, client.Delete(ctx, strconv.Itoa(i)))
+	}
+	before := fileSize(t, client.filePath)
+
+	require.NoError(t, client.compact())
+	assert.True(t, fileSize(t, client.filePath) < before)
+
+	got, err := client.Get(ctx, "0")
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+	require.NoError(t, client.Set(ctx, "1", value))
+
+	files, err := ioutil.ReadDir(tempDir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestClientCompactionOnStart(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClient(t)
+	defer os.RemoveAll(tempDir)
+
+	value := make([]byte, 10*1024)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, client.Set(ctx, strconv.Itoa(i), value))
+		require.NoError(t, client.Delete(ctx, strconv.Itoa(i)))
+	}
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	before := fileSize(t, client.filePath)
+	require.NoError(t, client.Close(ctx))
+
+	// Leftovers of an interrupted compaction are removed.
+	leftover := client.filePath + compactionSuffix + "123"
+	require.NoError(t, ioutil.WriteFile(leftover, []byte{}, 0600))
+
+	client, err := newFileStorageClient(client.filePath, clientSettings{timeout: time.Second, compactOnStart: true}, zap.NewNop())
+	require.NoError(t, err)
+	defer client.Close(ctx)
+	assert.True(t, fileSize(t, client.filePath) < before)
+	_, err = os.Stat(leftover)
+	assert.True(t, os.IsNotExist(err))
+
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestClientPeriodicCompaction(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClientWithSettings(t, clientSettings{timeout: time.Second, compactionInterval: time.Millisecond})
+	defer os.RemoveAll(tempDir)
+
+	// Operations keep working while the file is being compacted.
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		require.NoError(t, client.Set(ctx, key, []byte(key)))
+		value, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(key), value)
+		require.NoError(t, client.Delete(ctx, key))
+	}
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientCloseTwice(t *testing.T) {
+	ctx := context.Background()
+	client, tempDir := newTestClientWithSettings(t, clientSettings{timeout: time.Second, compactionInterval: time.Hour})
+	defer os.RemoveAll(tempDir)
+
+	require.NoError(t, client.Close(ctx))
+	assert.NoError(t, client.Close(ctx))
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func newTestClient(t *testing.T) (*fileStorageClient, string) {
+	return newTestClientWithSettings(t, clientSettings{timeout: time.Second, fsync: true})
+}
+
+func newTestClientWithSettings(t *testing.T, settings clientSettings) (*fileStorageClient, string) {
+	tempDir, err := ioutil.TempDir("", "file_storage")
+	require.NoError(t, err)
+
+	client, err := newFileStorageClient(filepath.Join(tempDir, "test"), settings, zap.NewNop())
+	require.NoError(t, err)
+	return client, tempDir
+}
@@ -0,0 +1,69 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+)
+
+// Config defines configuration for the file storage extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Directory is the directory where the files of each component are
+	// stored, it must exist and be writable. The default value is
+	// "/var/lib/otelcol/file_storage", or "%ProgramData%\Otelcol\FileStorage"
+	// on Windows.
+	Directory string `mapstructure:"directory"`
+
+	// Timeout is the maximum time to wait for the lock of a file, eg.: held
+	// by another Collector using the same directory. The default value is 1
+	// second.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// FSync indicates whether to call fsync after each write, trading
+	// throughput for durability in case of a crash. The default is false.
+	FSync bool `mapstructure:"fsync"`
+
+	// MaxSizeMiB is the maximum size, in MiB, of the data in each file. Once
+	// reached, writes fail with storage.ErrStorageFull until keys are deleted
+	// or expire. The default is 0, which means no limit.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+
+	// TTL is how long a key is kept since it was last written. Expired keys
+	// are not returned by the clients and are removed from the files when
+	// they are opened or compacted, and by writes at most once per TTL. The
+	// default is 0, keys never expire.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// Compaction configures the compaction of the files, which reclaims the
+	// space of deleted and expired keys.
+	Compaction CompactionConfig `mapstructure:"compaction"`
+}
+
+// CompactionConfig defines when the files are compacted. The files never
+// shrink otherwise, the space of deleted keys is only reused by later writes.
+type CompactionConfig struct {
+	// OnStart indicates whether to compact each file when it is opened, before
+	// it is used by its component. The default is false.
+	OnStart bool `mapstructure:"on_start"`
+
+	// Interval is how often to compact the files while they are in use, the
+	// component is blocked while its file is compacted. The default is 0,
+	// which disables it.
+	Interval time.Duration `mapstructure:"interval"`
+}
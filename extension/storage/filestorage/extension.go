@@ -0,0 +1,119 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+// localFileStorage implements storage.Extension keeping the data of each
+// component in its own file under the configured directory.
+type localFileStorage struct {
+	logger    *zap.Logger
+	directory string
+	settings  clientSettings
+}
+
+var _ storage.Extension = (*localFileStorage)(nil)
+
+func newLocalFileStorage(config *Config, logger *zap.Logger) (*localFileStorage, error) {
+	info, err := os.Stat(config.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("%q \"directory\" is not accessible: %v", config.Name(), err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%q \"directory\" %q is not a directory", config.Name(), config.Directory)
+	}
+
+	if config.Timeout < 0 {
+		return nil, fmt.Errorf("%q config cannot have a negative \"timeout\"", config.Name())
+	}
+	if config.MaxSizeMiB < 0 {
+		return nil, fmt.Errorf("%q config cannot have a negative \"max_size_mib\"", config.Name())
+	}
+	if config.TTL < 0 {
+		return nil, fmt.Errorf("%q config cannot have a negative \"ttl\"", config.Name())
+	}
+	if config.Compaction.Interval < 0 {
+		return nil, fmt.Errorf("%q config cannot have a negative \"compaction.interval\"", config.Name())
+	}
+
+	return &localFileStorage{
+		logger:    logger,
+		directory: filepath.Clean(config.Directory),
+		settings: clientSettings{
+			timeout:            config.Timeout,
+			fsync:              config.FSync,
+			maxSize:            config.MaxSizeMiB * 1024 * 1024,
+			ttl:                config.TTL,
+			compactOnStart:     config.Compaction.OnStart,
+			compactionInterval: config.Compaction.Interval,
+		},
+	}, nil
+}
+
+// Start does nothing, the files are opened when clients are requested.
+func (lfs *localFileStorage) Start(host component.Host) error {
+	return nil
+}
+
+// Shutdown does nothing, the clients are closed by their owners.
+func (lfs *localFileStorage) Shutdown() error {
+	return nil
+}
+
+// GetClient returns a storage client backed by a file dedicated to the given
+// component.
+func (lfs *localFileStorage) GetClient(_ context.Context, kind storage.Kind, name string) (storage.Client, error) {
+	fileName := sanitize(fmt.Sprintf("%s_%s", kind, name))
+	client, err := newFileStorageClient(filepath.Join(lfs.directory, fileName), lfs.settings, lfs.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	lfs.logger.Debug("Created storage client", zap.String("file", fileName))
+	return client, nil
+}
+
+// sanitize replaces the characters that are not safe in a file name, eg.:
+// the "/" on "receiver_filelog/1", by "~" followed by their hexadecimal
+// code. The result is unique for each input.
+func sanitize(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		if isSafeChar(r) {
+			sb.WriteRune(r)
+			continue
+		}
+		fmt.Fprintf(&sb, "~%04X", r)
+	}
+	return sb.String()
+}
+
+func isSafeChar(r rune) bool {
+	return ('a' <= r && r <= 'z') ||
+		('A' <= r && r <= 'Z') ||
+		('0' <= r && r <= '9') ||
+		r == '.' || r == '_' || r == '-'
+}
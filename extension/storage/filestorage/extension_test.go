@@ -0,0 +1,120 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+func TestExtensionClientsAreNamespaced(t *testing.T) {
+	ctx := context.Background()
+	ext, tempDir := newTestExtension(t)
+	defer os.RemoveAll(tempDir)
+
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	defer ext.Shutdown()
+
+	client1, err := ext.GetClient(ctx, storage.KindReceiver, "filelog/1")
+	require.NoError(t, err)
+	defer client1.Close(ctx)
+
+	client2, err := ext.GetClient(ctx, storage.KindExporter, "filelog/1")
+	require.NoError(t, err)
+	defer client2.Close(ctx)
+
+	require.NoError(t, client1.Set(ctx, "key", []byte("value1")))
+	require.NoError(t, client2.Set(ctx, "key", []byte("value2")))
+
+	value, err := client1.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+
+	value, err = client2.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value2"), value)
+
+	files, err := ioutil.ReadDir(tempDir)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.ElementsMatch(t, []string{"receiver_filelog~002F1", "exporter_filelog~002F1"}, names)
+}
+
+func TestExtensionDataSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	ext, tempDir := newTestExtension(t)
+	defer os.RemoveAll(tempDir)
+
+	client, err := ext.GetClient(ctx, storage.KindReceiver, "filelog")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "checkpoint", []byte("42")))
+	require.NoError(t, client.Close(ctx))
+
+	// A new instance, eg.: after a restart, can read the data back.
+	ext, err = newLocalFileStorage(&Config{Directory: tempDir}, zap.NewNop())
+	require.NoError(t, err)
+	client, err = ext.GetClient(ctx, storage.KindReceiver, "filelog")
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	value, err := client.Get(ctx, "checkpoint")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("42"), value)
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "receiver_filelog", want: "receiver_filelog"},
+		{name: "receiver_filelog/1", want: "receiver_filelog~002F1"},
+		{name: "exporter_otlp/a b", want: "exporter_otlp~002Fa~0020b"},
+		{name: "exporter_../x", want: "exporter_..~002Fx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sanitize(tt.name))
+		})
+	}
+}
+
+func TestDirectoryIsCleaned(t *testing.T) {
+	ext, tempDir := newTestExtension(t)
+	defer os.RemoveAll(tempDir)
+	assert.Equal(t, filepath.Clean(tempDir), ext.directory)
+}
+
+func newTestExtension(t *testing.T) (*localFileStorage, string) {
+	tempDir, err := ioutil.TempDir("", "file_storage")
+	require.NoError(t, err)
+
+	ext, err := newLocalFileStorage(&Config{Directory: tempDir}, zap.NewNop())
+	require.NoError(t, err)
+	return ext, tempDir
+}
@@ -0,0 +1,92 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/config/configcheck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := Factory{}
+	assert.Equal(t, typeStr, factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	assert.Equal(t, defaultDirectory(), cfg.Directory)
+	assert.Equal(t, defaultTimeout, cfg.Timeout)
+	assert.False(t, cfg.FSync)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "file_storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Directory = tempDir
+
+	ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
+
+func TestFactory_CreateExtensionInvalidDirectory(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "file_storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	file := filepath.Join(tempDir, "file")
+	require.NoError(t, ioutil.WriteFile(file, []byte{}, 0600))
+
+	factory := Factory{}
+	for _, dir := range []string{filepath.Join(tempDir, "missing"), file} {
+		cfg := factory.CreateDefaultConfig().(*Config)
+		cfg.Directory = dir
+		ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+		assert.Error(t, err)
+		assert.Nil(t, ext)
+	}
+}
+
+func TestFactory_CreateExtensionNegativeSettings(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "file_storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	factory := Factory{}
+	for _, modify := range []func(*Config){
+		func(cfg *Config) { cfg.Timeout = -time.Second },
+		func(cfg *Config) { cfg.MaxSizeMiB = -1 },
+		func(cfg *Config) { cfg.TTL = -time.Second },
+		func(cfg *Config) { cfg.Compaction.Interval = -time.Second },
+	} {
+		cfg := factory.CreateDefaultConfig().(*Config)
+		cfg.Directory = tempDir
+		modify(cfg)
+		ext, err := factory.CreateExtension(zap.NewNop(), cfg)
+		assert.Error(t, err)
+		assert.Nil(t, ext)
+	}
+}
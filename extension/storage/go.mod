@@ -0,0 +1,10 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage
+
+go 1.12
+
+require (
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/stretchr/testify v1.4.0
+	go.etcd.io/bbolt v1.3.5
+	go.uber.org/zap v1.13.0
+)
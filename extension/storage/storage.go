@@ -0,0 +1,93 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage defines the API implemented by the storage extensions.
+// Components use it to persist state, eg.: checkpoints, across restarts of
+// the Collector.
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector/extension"
+)
+
+// ErrStorageFull is returned by Client.Set when the value can't be stored
+// because the storage reached its maximum size. Components should treat it
+// as a transient condition, eg.: by dropping or retrying the data, since space
+// may be reclaimed later.
+var ErrStorageFull = errors.New("storage is full")
+
+// Kind identifies the kind of component requesting a storage client.
+type Kind int
+
+const (
+	// KindReceiver is used by receivers.
+	KindReceiver Kind = iota
+	// KindProcessor is used by processors.
+	KindProcessor
+	// KindExporter is used by exporters.
+	KindExporter
+	// KindExtension is used by extensions.
+	KindExtension
+)
+
+// String returns the name of the kind as used in configuration.
+func (k Kind) String() string {
+	switch k {
+	case KindReceiver:
+		return "receiver"
+	case KindProcessor:
+		return "processor"
+	case KindExporter:
+		return "exporter"
+	case KindExtension:
+		return "extension"
+	}
+	return "unknown"
+}
+
+// Extension is the interface that storage extensions must implement.
+type Extension interface {
+	extension.ServiceExtension
+
+	// GetClient returns a client for the component of the given kind and
+	// name, eg.: (KindReceiver, "filelog/1"). Each component gets its own
+	// namespace, so it doesn't need to worry about key collisions with other
+	// components. The client must be closed by the component when it is no
+	// longer needed.
+	GetClient(ctx context.Context, kind Kind, name string) (Client, error)
+}
+
+// Client is the interface that storage clients must implement. Methods
+// should only return an error if a problem occurred, this mirrors the
+// behavior of a Go map:
+//   - Get doesn't error if the key is not found, it just returns nil.
+//   - Set doesn't error if the key already exists, it overwrites the value.
+//   - Delete doesn't error if the key doesn't exist, it is a no-op.
+type Client interface {
+	// Get returns the value stored for the key or nil if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores the value for the key. It returns ErrStorageFull if the
+	// storage has no room left for the value.
+	Set(ctx context.Context, key string, value []byte) error
+
+	// Delete removes the key and its value.
+	Delete(ctx context.Context, key string) error
+
+	// Close releases the resources held by the client.
+	Close(ctx context.Context) error
+}
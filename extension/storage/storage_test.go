@@ -0,0 +1,43 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNopClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewNopClient()
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, client.Delete(ctx, "key"))
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestKindString(t *testing.T) {
+	assert.Equal(t, "receiver", KindReceiver.String())
+	assert.Equal(t, "processor", KindProcessor.String())
+	assert.Equal(t, "exporter", KindExporter.String())
+	assert.Equal(t, "extension", KindExtension.String())
+	assert.Equal(t, "unknown", Kind(-1).String())
+}
@@ -11,8 +11,19 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter v0.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kinesisexporter v0.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sapmexporter v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxcorrelationexporter v0.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter v0.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/stackdriverexporter v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/asapauthextension v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/awsproxy v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor v0.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver v0.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/collectdreceiver v0.0.0
@@ -56,4 +67,42 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zipki
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor => ./processor/k8sprocessor/
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr => ./internal/batchperresourceattr
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry => ./internal/resourcetotelemetry
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder => ./extension/httpforwarder
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ./extension/storage
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension => ./extension/oauth2clientauthextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ./extension/auth
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension => ./extension/oidcauthextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension => ./extension/basicauthextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension => ./extension/bearertokenauthextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension => ./extension/sigv4authextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/asapauthextension => ./extension/asapauthextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/client => ./internal/client
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension => ./extension/headerssetterextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/awsproxy => ./extension/awsproxy
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver => ./internal/httpserver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil => ./internal/aws/awsutil
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig => ./internal/k8sconfig
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator => ./internal/signalfxtranslator
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxcorrelationexporter => ./exporter/signalfxcorrelationexporter
+
 replace k8s.io/client-go => k8s.io/client-go v0.0.0-20190620085101-78d2af792bab
@@ -0,0 +1,54 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsutil
+
+// AWSSessionSettings defines the common session configuration of the
+// components talking to AWS.
+type AWSSessionSettings struct {
+	// Maximum number of concurrent calls to AWS.
+	NumberOfWorkers int `mapstructure:"num_workers"`
+	// AWS service endpoint to which the collector sends the data, by default
+	// it is derived from the region.
+	Endpoint string `mapstructure:"endpoint"`
+	// Number of seconds before timing out a request.
+	RequestTimeoutSeconds int `mapstructure:"request_timeout_seconds"`
+	// Maximum number of retries before abandoning an attempt to post data.
+	MaxRetries int `mapstructure:"max_retries"`
+	// Enable or disable TLS certificate verification.
+	NoVerifySSL bool `mapstructure:"no_verify_ssl"`
+	// Path to a PEM file with the CA certificates used to verify AWS, the
+	// system ones are used if empty.
+	CertificateFilePath string `mapstructure:"certificate_file_path"`
+	// Send the data to AWS through a proxy, by default the one set on the
+	// HTTPS_PROXY environment variable is used.
+	ProxyAddress string `mapstructure:"proxy_address"`
+	// Send the data to AWS service in a specific region.
+	Region string `mapstructure:"region"`
+	// Local mode to skip EC2 instance metadata check.
+	LocalMode bool `mapstructure:"local_mode"`
+	// Amazon Resource Name (ARN) of the AWS resource running the collector.
+	ResourceARN string `mapstructure:"resource_arn"`
+	// IAM role to send the data to a different account.
+	RoleARN string `mapstructure:"role_arn"`
+}
+
+// CreateDefaultSessionConfig returns the default session settings.
+func CreateDefaultSessionConfig() AWSSessionSettings {
+	return AWSSessionSettings{
+		NumberOfWorkers:       8,
+		RequestTimeoutSeconds: 30,
+		MaxRetries:            2,
+	}
+}
@@ -13,10 +13,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package awsxrayexporter
+package awsutil
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
@@ -34,15 +37,23 @@ import (
 	"golang.org/x/net/http2"
 )
 
-type connAttr interface {
-	newAWSSession(logger *zap.Logger, roleArn string, region string) (*session.Session, error)
-	getEC2Region(s *session.Session) (string, error)
+// ConnAttr creates the AWS sessions and gets the region from the EC2 instance
+// metadata, it allows tests to replace the calls to AWS.
+type ConnAttr interface {
+	NewAWSSession(logger *zap.Logger, roleArn string, region string) (*session.Session, error)
+	GetEC2Region(s *session.Session) (string, error)
 }
 
-// Conn implements connAttr interface.
-type Conn struct{}
+// Conn implements ConnAttr interface.
+// Conn creates the AWS sessions.
+type Conn struct {
+	// RoleSessionName is the name of the sessions of the assumed roles, it
+	// appears in CloudTrail logs. The default is generated by the AWS SDK.
+	RoleSessionName string
+}
 
-func (c *Conn) getEC2Region(s *session.Session) (string, error) {
+// GetEC2Region returns the region of the EC2 instance running the collector.
+func (c *Conn) GetEC2Region(s *session.Session) (string, error) {
 	return ec2metadata.New(s).Region()
 }
 
@@ -54,23 +65,24 @@ const (
 )
 
 // newHTTPClient returns new HTTP client instance with provided configuration.
-func newHTTPClient(logger *zap.Logger, maxIdle int, requestTimeout int, noVerify bool,
-	proxyAddress string) (*http.Client, error) {
+func newHTTPClient(logger *zap.Logger, cfg *AWSSessionSettings) (*http.Client, error) {
 	logger.Debug("Using proxy address: ",
-		zap.String("proxyAddr", proxyAddress),
+		zap.String("proxyAddr", cfg.ProxyAddress),
 	)
-	tls := &tls.Config{
-		InsecureSkipVerify: false,
+	tls, err := newTLSConfig(cfg)
+	if err != nil {
+		logger.Error("unable to load the CA certificates", zap.Error(err))
+		return nil, err
 	}
 
-	finalProxyAddress := getProxyAddress(proxyAddress)
+	finalProxyAddress := getProxyAddress(cfg.ProxyAddress)
 	proxyURL, err := getProxyURL(finalProxyAddress)
 	if err != nil {
 		logger.Error("unable to obtain proxy URL", zap.Error(err))
 		return nil, err
 	}
 	transport := &http.Transport{
-		MaxIdleConnsPerHost: maxIdle,
+		MaxIdleConnsPerHost: cfg.NumberOfWorkers,
 		TLSClientConfig:     tls,
 		Proxy:               http.ProxyURL(proxyURL),
 	}
@@ -80,11 +92,32 @@ func newHTTPClient(logger *zap.Logger, maxIdle int, requestTimeout int, noVerify
 	http2.ConfigureTransport(transport)
 	http := &http.Client{
 		Transport: transport,
-		Timeout:   time.Second * time.Duration(requestTimeout),
+		Timeout:   time.Second * time.Duration(cfg.RequestTimeoutSeconds),
 	}
 	return http, err
 }
 
+// newTLSConfig returns the TLS configuration used to reach AWS.
+func newTLSConfig(cfg *AWSSessionSettings) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.NoVerifySSL,
+	}
+	if cfg.CertificateFilePath == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := ioutil.ReadFile(cfg.CertificateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no CA certificate found in %q", cfg.CertificateFilePath)
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
 func getProxyAddress(proxyAddress string) string {
 	var finalProxyAddress string
 	if proxyAddress != "" {
@@ -110,13 +143,12 @@ func getProxyURL(finalProxyAddress string) (*url.URL, error) {
 }
 
 // GetAWSConfigSession returns AWS config and session instances.
-func GetAWSConfigSession(logger *zap.Logger, cn connAttr, cfg *Config) (*aws.Config, *session.Session, error) {
+func GetAWSConfigSession(logger *zap.Logger, cn ConnAttr, cfg *AWSSessionSettings) (*aws.Config, *session.Session, error) {
 	var s *session.Session
 	var err error
 	var awsRegion string
-	http, err := newHTTPClient(logger, cfg.NumberOfWorkers, cfg.RequestTimeoutSeconds, cfg.NoVerifySSL, cfg.ProxyAddress)
+	http, err := newHTTPClient(logger, cfg)
 	if err != nil {
-		logger.Error("unable to obtain proxy URL", zap.Error(err))
 		return nil, nil, err
 	}
 	regionEnv := os.Getenv("AWS_REGION")
@@ -131,7 +163,7 @@ func GetAWSConfigSession(logger *zap.Logger, cn connAttr, cfg *Config) (*aws.Con
 		if err != nil {
 			logger.Error("Unable to retrieve default session", zap.Error(err))
 		} else {
-			awsRegion, err = cn.getEC2Region(es)
+			awsRegion, err = cn.GetEC2Region(es)
 			if err != nil {
 				logger.Error("Unable to retrieve the region from the EC2 instance", zap.Error(err))
 			} else {
@@ -144,7 +176,7 @@ func GetAWSConfigSession(logger *zap.Logger, cn connAttr, cfg *Config) (*aws.Con
 		logger.Error(msg)
 		return nil, nil, awserr.New("NoAwsRegion", msg, nil)
 	}
-	s, err = cn.newAWSSession(logger, cfg.RoleARN, awsRegion)
+	s, err = cn.NewAWSSession(logger, cfg.RoleARN, awsRegion)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,9 +192,11 @@ func GetAWSConfigSession(logger *zap.Logger, cn connAttr, cfg *Config) (*aws.Con
 }
 
 // ProxyServerTransport configures HTTP transport for TCP Proxy Server.
-func ProxyServerTransport(logger *zap.Logger, config *Config) (*http.Transport, error) {
-	tls := &tls.Config{
-		InsecureSkipVerify: config.NoVerifySSL,
+func ProxyServerTransport(logger *zap.Logger, config *AWSSessionSettings) (*http.Transport, error) {
+	tls, err := newTLSConfig(config)
+	if err != nil {
+		logger.Error("unable to load the CA certificates", zap.Error(err))
+		return nil, err
 	}
 
 	proxyAddr := getProxyAddress(config.ProxyAddress)
@@ -192,7 +226,9 @@ func ProxyServerTransport(logger *zap.Logger, config *Config) (*http.Transport,
 	return transport, nil
 }
 
-func (c *Conn) newAWSSession(logger *zap.Logger, roleArn string, region string) (*session.Session, error) {
+// NewAWSSession returns a session using the default credentials chain or, if
+// roleArn is set, the credentials of the assumed role.
+func (c *Conn) NewAWSSession(logger *zap.Logger, roleArn string, region string) (*session.Session, error) {
 	var s *session.Session
 	var err error
 	if roleArn == "" {
@@ -201,7 +237,7 @@ func (c *Conn) newAWSSession(logger *zap.Logger, roleArn string, region string)
 			return s, err
 		}
 	} else {
-		stsCreds, _ := getSTSCreds(logger, region, roleArn)
+		stsCreds, _ := getSTSCreds(logger, region, roleArn, c.RoleSessionName)
 
 		s, err = session.NewSession(&aws.Config{
 			Credentials: stsCreds,
@@ -218,13 +254,13 @@ func (c *Conn) newAWSSession(logger *zap.Logger, roleArn string, region string)
 // getSTSCreds gets STS credentials from regional endpoint. ErrCodeRegionDisabledException is received if the
 // STS regional endpoint is disabled. In this case STS credentials are fetched from STS primary regional endpoint
 // in the respective AWS partition.
-func getSTSCreds(logger *zap.Logger, region string, roleArn string) (*credentials.Credentials, error) {
+func getSTSCreds(logger *zap.Logger, region string, roleArn string, sessionName string) (*credentials.Credentials, error) {
 	t, err := getDefaultSession(logger)
 	if err != nil {
 		return nil, err
 	}
 
-	stsCred := getSTSCredsFromRegionEndpoint(logger, t, region, roleArn)
+	stsCred := getSTSCredsFromRegionEndpoint(logger, t, region, roleArn, sessionName)
 	// Make explicit call to fetch credentials.
 	_, err = stsCred.Get()
 	if err != nil {
@@ -233,7 +269,7 @@ func getSTSCreds(logger *zap.Logger, region string, roleArn string) (*credential
 			switch aerr.Code() {
 			case sts.ErrCodeRegionDisabledException:
 				logger.Error("Region ", zap.String("region", region), zap.String("error", aerr.Error()))
-				stsCred = getSTSCredsFromPrimaryRegionEndpoint(logger, t, roleArn, region)
+				stsCred = getSTSCredsFromPrimaryRegionEndpoint(logger, t, roleArn, region, sessionName)
 			}
 		}
 	}
@@ -244,7 +280,7 @@ func getSTSCreds(logger *zap.Logger, region string, roleArn string) (*credential
 // AWS STS recommends that you provide both the Region and endpoint when you make calls to a Regional endpoint.
 // Reference: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_temp_enable-regions.html#id_credentials_temp_enable-regions_writing_code
 func getSTSCredsFromRegionEndpoint(logger *zap.Logger, sess *session.Session, region string,
-	roleArn string) *credentials.Credentials {
+	roleArn string, sessionName string) *credentials.Credentials {
 	regionalEndpoint := getSTSRegionalEndpoint(region)
 	// if regionalEndpoint is "", the STS endpoint is Global endpoint for classic regions except ap-east-1 - (HKG)
 	// for other opt-in regions, region value will create STS regional endpoint.
@@ -252,21 +288,25 @@ func getSTSCredsFromRegionEndpoint(logger *zap.Logger, sess *session.Session, re
 	c := &aws.Config{Region: aws.String(region), Endpoint: &regionalEndpoint}
 	st := sts.New(sess, c)
 	logger.Info("STS Endpoint ", zap.String("endpoint", st.Endpoint))
-	return stscreds.NewCredentialsWithClient(st, roleArn)
+	return stscreds.NewCredentialsWithClient(st, roleArn, func(p *stscreds.AssumeRoleProvider) {
+		if sessionName != "" {
+			p.RoleSessionName = sessionName
+		}
+	})
 }
 
 // getSTSCredsFromPrimaryRegionEndpoint fetches STS credentials for provided roleARN from primary region endpoint in
 // the respective partition.
 func getSTSCredsFromPrimaryRegionEndpoint(logger *zap.Logger, t *session.Session, roleArn string,
-	region string) *credentials.Credentials {
+	region string, sessionName string) *credentials.Credentials {
 	logger.Info("Credentials for provided RoleARN being fetched from STS primary region endpoint.")
 	partitionID := getPartition(region)
 	if partitionID == endpoints.AwsPartitionID {
-		return getSTSCredsFromRegionEndpoint(logger, t, endpoints.UsEast1RegionID, roleArn)
+		return getSTSCredsFromRegionEndpoint(logger, t, endpoints.UsEast1RegionID, roleArn, sessionName)
 	} else if partitionID == endpoints.AwsCnPartitionID {
-		return getSTSCredsFromRegionEndpoint(logger, t, endpoints.CnNorth1RegionID, roleArn)
+		return getSTSCredsFromRegionEndpoint(logger, t, endpoints.CnNorth1RegionID, roleArn, sessionName)
 	} else if partitionID == endpoints.AwsUsGovPartitionID {
-		return getSTSCredsFromRegionEndpoint(logger, t, endpoints.UsGovWest1RegionID, roleArn)
+		return getSTSCredsFromRegionEndpoint(logger, t, endpoints.UsGovWest1RegionID, roleArn, sessionName)
 	}
 
 	return nil
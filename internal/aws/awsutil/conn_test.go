@@ -12,19 +12,20 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package awsxrayexporter
+package awsutil
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
 	"path"
 	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/open-telemetry/opentelemetry-collector/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -35,7 +36,7 @@ type mockConn struct {
 	sn *session.Session
 }
 
-func (c *mockConn) getEC2Region(s *session.Session) (string, error) {
+func (c *mockConn) GetEC2Region(s *session.Session) (string, error) {
 	args := c.Called(nil)
 	errorStr := args.String(0)
 	var err error
@@ -46,20 +47,20 @@ func (c *mockConn) getEC2Region(s *session.Session) (string, error) {
 	return ec2Region, nil
 }
 
-func (c *mockConn) newAWSSession(logger *zap.Logger, roleArn string, region string) (*session.Session, error) {
+func (c *mockConn) NewAWSSession(logger *zap.Logger, roleArn string, region string) (*session.Session, error) {
 	return c.sn, nil
 }
 
 // fetch region value from ec2 meta data service
 func TestEC2Session(t *testing.T) {
 	logger := zap.NewNop()
-	xrayExporterCfg := loadExporterConfig(t)
+	sessionCfg := CreateDefaultSessionConfig()
 	m := new(mockConn)
 	m.On("getEC2Region", nil).Return("").Once()
 	var expectedSession *session.Session
 	expectedSession, _ = session.NewSession()
 	m.sn = expectedSession
-	cfg, s, err := GetAWSConfigSession(logger, m, xrayExporterCfg)
+	cfg, s, err := GetAWSConfigSession(logger, m, &sessionCfg)
 	assert.Equal(t, s, expectedSession, "Expect the session object is not overridden")
 	assert.Equal(t, *cfg.Region, ec2Region, "Region value fetched from ec2-metadata service")
 	assert.Nil(t, err)
@@ -68,7 +69,7 @@ func TestEC2Session(t *testing.T) {
 // fetch region value from environment variable
 func TestRegionEnv(t *testing.T) {
 	logger := zap.NewNop()
-	xrayExporterCfg := loadExporterConfig(t)
+	sessionCfg := CreateDefaultSessionConfig()
 	region := "us-west-2"
 	env := stashEnv()
 	defer popEnv(env)
@@ -78,22 +79,35 @@ func TestRegionEnv(t *testing.T) {
 	var expectedSession *session.Session
 	expectedSession, _ = session.NewSession()
 	m.sn = expectedSession
-	cfg, s, err := GetAWSConfigSession(logger, m, xrayExporterCfg)
+	cfg, s, err := GetAWSConfigSession(logger, m, &sessionCfg)
 	assert.Equal(t, s, expectedSession, "Expect the session object is not overridden")
 	assert.Equal(t, *cfg.Region, region, "Region value fetched from environment")
 	assert.Nil(t, err)
 }
 
-func loadExporterConfig(t *testing.T) *Config {
-	factories, err := config.ExampleComponents()
-	assert.Nil(t, err)
-	factory := &Factory{}
-	factories.Exporters[factory.Type()] = factory
-	otelcfg, _ := config.LoadConfigFile(
-		t, path.Join(".", "testdata", "config.yaml"), factories,
-	)
-	xrayExporterCfg := otelcfg.Exporters["awsxray"].(*Config)
-	return xrayExporterCfg
+func TestCertificateFile(t *testing.T) {
+	sessionCfg := CreateDefaultSessionConfig()
+	sessionCfg.CertificateFilePath = path.Join(".", "testdata", "ca.crt")
+	tlsConfig, err := newTLSConfig(&sessionCfg)
+	require.NoError(t, err)
+	assert.NotNil(t, tlsConfig.RootCAs)
+
+	transport, err := ProxyServerTransport(zap.NewNop(), &sessionCfg)
+	require.NoError(t, err)
+	assert.Equal(t, tlsConfig.RootCAs, transport.TLSClientConfig.RootCAs)
+
+	sessionCfg.CertificateFilePath = path.Join(".", "testdata", "missing.crt")
+	_, err = newTLSConfig(&sessionCfg)
+	assert.Error(t, err)
+
+	notPEM, err := ioutil.TempFile("", "ca")
+	require.NoError(t, err)
+	defer os.Remove(notPEM.Name())
+	notPEM.WriteString("not a certificate")
+	notPEM.Close()
+	sessionCfg.CertificateFilePath = notPEM.Name()
+	_, err = newTLSConfig(&sessionCfg)
+	assert.Error(t, err)
 }
 
 func stashEnv() []string {
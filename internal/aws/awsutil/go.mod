@@ -0,0 +1,10 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil
+
+go 1.12
+
+require (
+	github.com/aws/aws-sdk-go v1.23.12
+	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.10.0
+	golang.org/x/net v0.0.0-20190923162816-aa69164e4478
+)
@@ -0,0 +1,139 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batchperresourceattr implements consumers that split a batch into
+// multiple batches, one per distinct value of a given resource attribute. It
+// is intended for exporters that need all the data of a request to share the
+// same value of some attribute, eg.: an access token or a tenant.
+package batchperresourceattr
+
+import (
+	"context"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/oterr"
+)
+
+type batchTraces struct {
+	attrKey string
+	next    consumer.TraceConsumer
+}
+
+var _ consumer.TraceConsumer = (*batchTraces)(nil)
+
+// NewBatchPerResourceTraces returns a consumer.TraceConsumer that splits the
+// received batch by the value of the attrKey resource label and forwards each
+// resulting batch to next.
+func NewBatchPerResourceTraces(attrKey string, next consumer.TraceConsumer) consumer.TraceConsumer {
+	return &batchTraces{
+		attrKey: attrKey,
+		next:    next,
+	}
+}
+
+// ConsumeTraceData implements the consumer.TraceConsumer interface.
+func (bt *batchTraces) ConsumeTraceData(ctx context.Context, td consumerdata.TraceData) error {
+	// Keep the order in which the values were first seen so the batches are
+	// forwarded in a deterministic order.
+	var values []string
+	spansByValue := make(map[string][]*tracepb.Span)
+	for _, span := range td.Spans {
+		value := AttributeValue(bt.attrKey, td.Resource, span.GetResource())
+		if _, ok := spansByValue[value]; !ok {
+			values = append(values, value)
+		}
+		spansByValue[value] = append(spansByValue[value], span)
+	}
+
+	if len(values) <= 1 {
+		// Nothing to split, avoid the allocations.
+		return bt.next.ConsumeTraceData(ctx, td)
+	}
+
+	var errs []error
+	for _, value := range values {
+		batch := consumerdata.TraceData{
+			Node:         td.Node,
+			Resource:     td.Resource,
+			Spans:        spansByValue[value],
+			SourceFormat: td.SourceFormat,
+		}
+		if err := bt.next.ConsumeTraceData(ctx, batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return oterr.CombineErrors(errs)
+}
+
+type batchMetrics struct {
+	attrKey string
+	next    consumer.MetricsConsumer
+}
+
+var _ consumer.MetricsConsumer = (*batchMetrics)(nil)
+
+// NewBatchPerResourceMetrics returns a consumer.MetricsConsumer that splits
+// the received batch by the value of the attrKey resource label and forwards
+// each resulting batch to next.
+func NewBatchPerResourceMetrics(attrKey string, next consumer.MetricsConsumer) consumer.MetricsConsumer {
+	return &batchMetrics{
+		attrKey: attrKey,
+		next:    next,
+	}
+}
+
+// ConsumeMetricsData implements the consumer.MetricsConsumer interface.
+func (bm *batchMetrics) ConsumeMetricsData(ctx context.Context, md consumerdata.MetricsData) error {
+	var values []string
+	metricsByValue := make(map[string][]*metricspb.Metric)
+	for _, metric := range md.Metrics {
+		value := AttributeValue(bm.attrKey, md.Resource, metric.GetResource())
+		if _, ok := metricsByValue[value]; !ok {
+			values = append(values, value)
+		}
+		metricsByValue[value] = append(metricsByValue[value], metric)
+	}
+
+	if len(values) <= 1 {
+		return bm.next.ConsumeMetricsData(ctx, md)
+	}
+
+	var errs []error
+	for _, value := range values {
+		batch := consumerdata.MetricsData{
+			Node:     md.Node,
+			Resource: md.Resource,
+			Metrics:  metricsByValue[value],
+		}
+		if err := bm.next.ConsumeMetricsData(ctx, batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return oterr.CombineErrors(errs)
+}
+
+// AttributeValue returns the value of the attrKey label that applies to an
+// item (span or metric) of a batch. As in the OpenCensus data model, if the
+// item has its own resource it replaces the resource of the batch. An empty
+// string is returned if the label is not present.
+func AttributeValue(attrKey string, batchResource, itemResource *resourcepb.Resource) string {
+	if itemResource != nil {
+		return itemResource.GetLabels()[attrKey]
+	}
+	return batchResource.GetLabels()[attrKey]
+}
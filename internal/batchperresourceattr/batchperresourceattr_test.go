@@ -0,0 +1,152 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchperresourceattr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/exporter/exportertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAttrKey = "tenant"
+
+func TestSplitTracesOneValue(t *testing.T) {
+	sink := new(exportertest.SinkTraceExporter)
+	bpr := NewBatchPerResourceTraces(testAttrKey, sink)
+
+	td := consumerdata.TraceData{
+		Resource: resourceWithValue("1"),
+		Spans:    []*tracepb.Span{{Name: "a"}, {Name: "b"}},
+	}
+	require.NoError(t, bpr.ConsumeTraceData(context.Background(), td))
+
+	got := sink.AllTraces()
+	require.Len(t, got, 1)
+	assert.Equal(t, td, got[0])
+}
+
+func TestSplitTracesMultipleValues(t *testing.T) {
+	sink := new(exportertest.SinkTraceExporter)
+	bpr := NewBatchPerResourceTraces(testAttrKey, sink)
+
+	td := consumerdata.TraceData{
+		Resource: resourceWithValue("1"),
+		Spans: []*tracepb.Span{
+			{Name: "a"},
+			{Name: "b", Resource: resourceWithValue("2")},
+			{Name: "c", Resource: &resourcepb.Resource{}},
+			{Name: "d"},
+			{Name: "e", Resource: resourceWithValue("2")},
+		},
+		SourceFormat: "test",
+	}
+	require.NoError(t, bpr.ConsumeTraceData(context.Background(), td))
+
+	got := sink.AllTraces()
+	require.Len(t, got, 3)
+	assert.Equal(t, []*tracepb.Span{td.Spans[0], td.Spans[3]}, got[0].Spans)
+	assert.Equal(t, []*tracepb.Span{td.Spans[1], td.Spans[4]}, got[1].Spans)
+	assert.Equal(t, []*tracepb.Span{td.Spans[2]}, got[2].Spans)
+	for _, batch := range got {
+		assert.Equal(t, td.Resource, batch.Resource)
+		assert.Equal(t, td.SourceFormat, batch.SourceFormat)
+	}
+}
+
+func TestSplitTracesReturnsErrors(t *testing.T) {
+	next := &errTraceConsumer{err: errors.New("consumer error")}
+	bpr := NewBatchPerResourceTraces(testAttrKey, next)
+
+	td := consumerdata.TraceData{
+		Spans: []*tracepb.Span{
+			{Name: "a", Resource: resourceWithValue("1")},
+			{Name: "b", Resource: resourceWithValue("2")},
+		},
+	}
+	assert.Error(t, bpr.ConsumeTraceData(context.Background(), td))
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestSplitMetricsOneValue(t *testing.T) {
+	sink := new(exportertest.SinkMetricsExporter)
+	bpr := NewBatchPerResourceMetrics(testAttrKey, sink)
+
+	md := consumerdata.MetricsData{
+		Resource: resourceWithValue("1"),
+		Metrics:  []*metricspb.Metric{newMetric("a", nil), newMetric("b", resourceWithValue("1"))},
+	}
+	require.NoError(t, bpr.ConsumeMetricsData(context.Background(), md))
+
+	got := sink.AllMetrics()
+	require.Len(t, got, 1)
+	assert.Equal(t, md, got[0])
+}
+
+func TestSplitMetricsMultipleValues(t *testing.T) {
+	sink := new(exportertest.SinkMetricsExporter)
+	bpr := NewBatchPerResourceMetrics(testAttrKey, sink)
+
+	md := consumerdata.MetricsData{
+		Metrics: []*metricspb.Metric{
+			newMetric("a", resourceWithValue("1")),
+			newMetric("b", nil),
+			newMetric("c", resourceWithValue("1")),
+		},
+	}
+	require.NoError(t, bpr.ConsumeMetricsData(context.Background(), md))
+
+	got := sink.AllMetrics()
+	require.Len(t, got, 2)
+	assert.Equal(t, []*metricspb.Metric{md.Metrics[0], md.Metrics[2]}, got[0].Metrics)
+	assert.Equal(t, []*metricspb.Metric{md.Metrics[1]}, got[1].Metrics)
+}
+
+func TestAttributeValue(t *testing.T) {
+	assert.Equal(t, "", AttributeValue(testAttrKey, nil, nil))
+	assert.Equal(t, "1", AttributeValue(testAttrKey, resourceWithValue("1"), nil))
+	assert.Equal(t, "2", AttributeValue(testAttrKey, resourceWithValue("1"), resourceWithValue("2")))
+	assert.Equal(t, "", AttributeValue(testAttrKey, resourceWithValue("1"), &resourcepb.Resource{}))
+}
+
+func resourceWithValue(value string) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Labels: map[string]string{testAttrKey: value},
+	}
+}
+
+func newMetric(name string, res *resourcepb.Resource) *metricspb.Metric {
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{Name: name},
+		Resource:         res,
+	}
+}
+
+type errTraceConsumer struct {
+	err   error
+	calls int
+}
+
+func (c *errTraceConsumer) ConsumeTraceData(context.Context, consumerdata.TraceData) error {
+	c.calls++
+	return c.err
+}
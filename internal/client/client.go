@@ -0,0 +1,62 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client carries the metadata of the requests received by the
+// receivers, eg.: the HTTP headers, through the pipelines so that later
+// components, like the exporters, can use it.
+package client
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type metadataKey struct{}
+
+// Metadata holds the headers, or gRPC metadata, of the request that brought
+// the data into the Collector.
+type Metadata map[string][]string
+
+// Get returns the values of the key, ignoring its case since HTTP
+// canonicalizes the header names while gRPC lowercases them.
+func (m Metadata) Get(key string) []string {
+	if values, ok := m[key]; ok {
+		return values
+	}
+	for k, values := range m {
+		if strings.EqualFold(k, key) {
+			return values
+		}
+	}
+	return nil
+}
+
+// NewContext returns a context carrying the metadata.
+func NewContext(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// FromContext returns the metadata carried by the context. If none was set
+// with NewContext it falls back to the incoming gRPC metadata.
+func FromContext(ctx context.Context) (Metadata, bool) {
+	if md, ok := ctx.Value(metadataKey{}).(Metadata); ok {
+		return md, true
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		return Metadata(md), true
+	}
+	return nil, false
+}
@@ -0,0 +1,49 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNewContext(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Tenant", "acme")
+
+	md, ok := FromContext(NewContext(context.Background(), Metadata(header)))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"acme"}, md.Get("X-Tenant"))
+	assert.Equal(t, []string{"acme"}, md.Get("x-tenant"))
+	assert.Nil(t, md.Get("x-other"))
+}
+
+func TestFromContextGRPC(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "acme"))
+
+	md, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"acme"}, md.Get("X-Tenant"))
+}
+
+func TestFromContextEmpty(t *testing.T) {
+	md, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	assert.Nil(t, md.Get("x-tenant"))
+}
@@ -0,0 +1,8 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/internal/client
+
+go 1.12
+
+require (
+	github.com/stretchr/testify v1.4.0
+	google.golang.org/grpc v1.25.1
+)
@@ -0,0 +1,11 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver
+
+go 1.12
+
+require (
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth v0.0.0
+	github.com/stretchr/testify v1.4.0
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../../extension/auth
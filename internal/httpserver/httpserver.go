@@ -0,0 +1,191 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpserver provides the settings shared by the receivers running
+// an HTTP server, so that features like TLS or CORS are implemented once and
+// behave the same way on every receiver.
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+// Settings defines the settings of an HTTP server. The endpoint isn't part
+// of them since receivers already get it from their ReceiverSettings. It is
+// meant to be embedded, squashed, in the configuration of the receivers.
+type Settings struct {
+	// TLSSetting enables TLS on the server when set.
+	TLSSetting *TLSSetting `mapstructure:"tls_settings"`
+
+	// CORSAllowedOrigins lists the origins allowed to send requests from a
+	// browser. An origin may contain a wildcard, eg.: "https://*.example.com",
+	// and "*" allows any origin. CORS is disabled when empty.
+	CORSAllowedOrigins []string `mapstructure:"cors_allowed_origins"`
+
+	// MaxRequestBodySize is the maximum size, in bytes, of the body of the
+	// requests. Requests with a bigger body fail to be read. There is no
+	// limit when zero.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+
+	// Authenticator is the name of the server authenticator extension, eg.:
+	// "oidc", validating the credentials of the requests. The extension must
+	// also be enabled in the service. Requests aren't authenticated when
+	// empty.
+	Authenticator string `mapstructure:"authenticator"`
+
+	// ReadTimeout is the maximum duration for reading a request, including
+	// its body. There is no timeout when zero.
+	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+
+	// WriteTimeout is the maximum duration for writing a response, measured
+	// from the end of the request headers. There is no timeout when zero.
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+}
+
+// TLSSetting defines the certificates used by the server.
+type TLSSetting struct {
+	// CertFile is the path to the certificate of the server.
+	CertFile string `mapstructure:"cert_file"`
+
+	// KeyFile is the path to the private key of the server.
+	KeyFile string `mapstructure:"key_file"`
+
+	// ClientCAFile is the path to the CA certificates used to verify the
+	// certificates of the clients. When set, clients must present a valid
+	// certificate.
+	ClientCAFile string `mapstructure:"client_ca_file"`
+}
+
+// Listen returns a listener on the endpoint, serving TLS if configured.
+func (s *Settings) Listen(endpoint string) (net.Listener, error) {
+	if s.TLSSetting == nil {
+		return net.Listen("tcp", endpoint)
+	}
+
+	tlsCfg, err := s.TLSSetting.loadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewListener(listener, tlsCfg), nil
+}
+
+func (t *TLSSetting) loadTLSConfig() (*tls.Config, error) {
+	if t.CertFile == "" || t.KeyFile == "" {
+		return nil, errors.New("both \"cert_file\" and \"key_file\" must be set to enable TLS")
+	}
+	cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %v", err)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if t.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(t.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse client CA file %q", t.ClientCAFile)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, nil
+}
+
+// Handler wraps the handler of a receiver applying the settings: body size
+// limit, CORS and authentication. CORS preflight requests are answered before
+// authentication since browsers don't send credentials with them. The
+// authenticator is looked up when called, so receivers must call it from
+// Start, once the extensions are started.
+func (s *Settings) Handler(handler http.Handler) (http.Handler, error) {
+	if s.Authenticator != "" {
+		authenticator, err := auth.GetServerAuthenticator(s.Authenticator)
+		if err != nil {
+			return nil, err
+		}
+		handler = auth.NewHTTPHandler(authenticator, handler)
+	}
+	if s.MaxRequestBodySize > 0 {
+		handler = maxBodySizeHandler(s.MaxRequestBodySize, handler)
+	}
+	if len(s.CORSAllowedOrigins) > 0 {
+		handler = corsHandler(s.CORSAllowedOrigins, handler)
+	}
+	return handler, nil
+}
+
+func maxBodySizeHandler(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func corsHandler(allowedOrigins []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !originAllowed(allowedOrigins, origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func originAllowed(allowedOrigins []string, origin string) bool {
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		if idx := strings.Index(allowed, "*"); idx >= 0 {
+			prefix, suffix := allowed[:idx], allowed[idx+1:]
+			if len(origin) >= len(prefix)+len(suffix) &&
+				strings.HasPrefix(origin, prefix) &&
+				strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth"
+)
+
+// writeCertificate writes a self-signed certificate for localhost and its key
+// in dir.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+	return certFile, keyFile
+}
+
+func TestListenTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpserver")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+
+	s := &Settings{
+		TLSSetting: &TLSSetting{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+	}
+	listener, err := s.Listen("127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	resp, err := client.Get("https://" + listener.Addr().String())
+	require.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "ok", string(body))
+}
+
+func TestListenInvalidTLS(t *testing.T) {
+	s := &Settings{TLSSetting: &TLSSetting{CertFile: "cert.pem"}}
+	_, err := s.Listen("127.0.0.1:0")
+	assert.Error(t, err)
+
+	s = &Settings{TLSSetting: &TLSSetting{CertFile: "missing.pem", KeyFile: "missing.pem"}}
+	_, err = s.Listen("127.0.0.1:0")
+	assert.Error(t, err)
+}
+
+func TestHandlerMaxRequestBodySize(t *testing.T) {
+	s := &Settings{MaxRequestBodySize: 4}
+	handler, err := s.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}
+	}))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("1234"))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("12345"))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestHandlerCORS(t *testing.T) {
+	s := &Settings{CORSAllowedOrigins: []string{"https://app.example.com", "https://*.test.com"}}
+	called := false
+	handler, err := s.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		method     string
+		origin     string
+		preflight  bool
+		wantOrigin string
+		wantCalled bool
+		wantCode   int
+	}{
+		{
+			name:       "allowed",
+			method:     http.MethodPost,
+			origin:     "https://app.example.com",
+			wantOrigin: "https://app.example.com",
+			wantCalled: true,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "wildcard",
+			method:     http.MethodPost,
+			origin:     "https://foo.test.com",
+			wantOrigin: "https://foo.test.com",
+			wantCalled: true,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "not_allowed",
+			method:     http.MethodPost,
+			origin:     "https://other.com",
+			wantCalled: true,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "preflight",
+			method:     http.MethodOptions,
+			origin:     "https://app.example.com",
+			preflight:  true,
+			wantOrigin: "https://app.example.com",
+			wantCode:   http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+				req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantCalled, called)
+			assert.Equal(t, tt.wantOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			if tt.preflight {
+				assert.Equal(t, "Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+			}
+		})
+	}
+}
+
+type mockAuthenticator struct{}
+
+func (m *mockAuthenticator) Start(host component.Host) error {
+	return nil
+}
+
+func (m *mockAuthenticator) Shutdown() error {
+	return nil
+}
+
+func (m *mockAuthenticator) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	if len(headers["Authorization"]) == 0 {
+		return ctx, auth.ErrNotAuthenticated
+	}
+	return ctx, nil
+}
+
+func TestHandlerAuthenticator(t *testing.T) {
+	s := &Settings{Authenticator: "mockauth"}
+	_, err := s.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	assert.Error(t, err, "authenticator not started")
+
+	ext := auth.NewServerAuthenticatorExtension("mockauth", &mockAuthenticator{})
+	require.NoError(t, ext.Start(component.NewMockHost()))
+	defer ext.Shutdown()
+
+	handler, err := s.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Authorization", "token")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,8 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig
+
+go 1.13
+
+require (
+	github.com/stretchr/testify v1.4.0
+	k8s.io/client-go v12.0.0+incompatible
+)
@@ -0,0 +1,169 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8sconfig builds the clients used by the components talking to the
+// Kubernetes API from a common configuration.
+package k8sconfig
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// AuthType describes the type of authentication to use for the K8s API.
+type AuthType string
+
+const (
+	// AuthTypeNone means no auth is required.
+	AuthTypeNone AuthType = "none"
+	// AuthTypeServiceAccount means to use the built-in service account that
+	// K8s automatically provisions for each pod.
+	AuthTypeServiceAccount AuthType = "serviceAccount"
+	// AuthTypeKubeConfig uses local credentials like those used by kubectl.
+	AuthTypeKubeConfig AuthType = "kubeConfig"
+	// AuthTypeTLS uses a client certificate to authenticate.
+	AuthTypeTLS AuthType = "tls"
+)
+
+// serviceAccountCAFile is the CA mounted in every pod with a service account.
+const serviceAccountCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+var authTypes = map[AuthType]bool{
+	AuthTypeNone:           true,
+	AuthTypeServiceAccount: true,
+	AuthTypeKubeConfig:     true,
+	AuthTypeTLS:            true,
+}
+
+// APIConfig contains options relevant to connecting to the K8s API.
+type APIConfig struct {
+	// AuthType is how to authenticate to the K8s API server. This can be one
+	// of "none" (for no auth), "serviceAccount" (to use the standard service
+	// account token provided to the pod), "kubeConfig" (to use credentials
+	// from a kubeconfig file) or "tls" (to use a client certificate).
+	AuthType AuthType `mapstructure:"auth_type"`
+
+	// KubeConfigPath is the kubeconfig file used with the "kubeConfig" auth
+	// type. By default the KUBECONFIG environment variable or
+	// ~/.kube/config are used.
+	KubeConfigPath string `mapstructure:"kube_config_path"`
+
+	// TLS holds the client certificate used with the "tls" auth type.
+	TLS TLSConfig `mapstructure:"tls"`
+
+	// QPS is the maximum queries per second to the API server, zero uses the
+	// client default.
+	QPS float32 `mapstructure:"qps"`
+
+	// Burst is the maximum burst of queries to the API server, zero uses the
+	// client default.
+	Burst int `mapstructure:"burst"`
+}
+
+// TLSConfig holds the files used to authenticate with a client certificate.
+type TLSConfig struct {
+	// CAFile is the CA used to verify the API server, by default the one of
+	// the service account.
+	CAFile string `mapstructure:"ca_file"`
+	// CertFile is the client certificate.
+	CertFile string `mapstructure:"cert_file"`
+	// KeyFile is the key of the client certificate.
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// Validate validates the K8s API config.
+func (c APIConfig) Validate() error {
+	if !authTypes[c.AuthType] {
+		return fmt.Errorf("invalid authType for kubernetes: %v", c.AuthType)
+	}
+	if c.AuthType == AuthTypeTLS && (c.TLS.CertFile == "" || c.TLS.KeyFile == "") {
+		return fmt.Errorf("\"tls.cert_file\" and \"tls.key_file\" are required for the %q authType", AuthTypeTLS)
+	}
+	if c.QPS < 0 || c.Burst < 0 {
+		return fmt.Errorf("\"qps\" and \"burst\" cannot be negative")
+	}
+	return nil
+}
+
+// CreateRestConfig creates a Kubernetes API config from the given config.
+func CreateRestConfig(apiConf APIConfig) (*rest.Config, error) {
+	if err := apiConf.Validate(); err != nil {
+		return nil, err
+	}
+
+	var authConf *rest.Config
+	var err error
+	switch apiConf.AuthType {
+	case AuthTypeKubeConfig:
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		loadingRules.ExplicitPath = apiConf.KubeConfigPath
+		authConf, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to k8s with auth_type=%s: %v", AuthTypeKubeConfig, err)
+		}
+	case AuthTypeServiceAccount:
+		authConf, err = rest.InClusterConfig()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		// These auth types reach the API server through the service
+		// Kubernetes injects in every pod.
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, fmt.Errorf("unable to load k8s config, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be defined")
+		}
+		authConf = &rest.Config{
+			Host: "https://" + net.JoinHostPort(host, port),
+		}
+		if apiConf.AuthType == AuthTypeNone {
+			authConf.Insecure = true
+		} else {
+			caFile := apiConf.TLS.CAFile
+			if caFile == "" {
+				caFile = serviceAccountCAFile
+			}
+			authConf.TLSClientConfig = rest.TLSClientConfig{
+				CAFile:   caFile,
+				CertFile: apiConf.TLS.CertFile,
+				KeyFile:  apiConf.TLS.KeyFile,
+			}
+		}
+	}
+
+	if apiConf.QPS > 0 {
+		authConf.QPS = apiConf.QPS
+	}
+	if apiConf.Burst > 0 {
+		authConf.Burst = apiConf.Burst
+	}
+
+	return authConf, nil
+}
+
+// MakeClient creates a Kubernetes clientset from the given config.
+func MakeClient(apiConf APIConfig) (*kubernetes.Clientset, error) {
+	authConf, err := CreateRestConfig(apiConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(authConf)
+}
@@ -0,0 +1,128 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sconfig
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setEnv(t *testing.T, key, value string) func() {
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	return func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  APIConfig
+		wantErr bool
+	}{
+		{
+			name:   "serviceAccount",
+			config: APIConfig{AuthType: AuthTypeServiceAccount},
+		},
+		{
+			name:    "unknown",
+			config:  APIConfig{AuthType: "invalid"},
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			config:  APIConfig{},
+			wantErr: true,
+		},
+		{
+			name:    "tls_without_cert",
+			config:  APIConfig{AuthType: AuthTypeTLS},
+			wantErr: true,
+		},
+		{
+			name: "tls",
+			config: APIConfig{
+				AuthType: AuthTypeTLS,
+				TLS:      TLSConfig{CertFile: "client.crt", KeyFile: "client.key"},
+			},
+		},
+		{
+			name:    "negative_qps",
+			config:  APIConfig{AuthType: AuthTypeNone, QPS: -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateRestConfig_KubeConfig(t *testing.T) {
+	cfg, err := CreateRestConfig(APIConfig{
+		AuthType:       AuthTypeKubeConfig,
+		KubeConfigPath: path.Join(".", "testdata", "kubeconfig"),
+		QPS:            50,
+		Burst:          100,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://kubernetes.example.com:6443", cfg.Host)
+	assert.Equal(t, "test-token", cfg.BearerToken)
+	assert.Equal(t, float32(50), cfg.QPS)
+	assert.Equal(t, 100, cfg.Burst)
+}
+
+func TestCreateRestConfig_ServiceHost(t *testing.T) {
+	defer setEnv(t, "KUBERNETES_SERVICE_HOST", "10.0.0.1")()
+	defer setEnv(t, "KUBERNETES_SERVICE_PORT", "443")()
+
+	cfg, err := CreateRestConfig(APIConfig{AuthType: AuthTypeNone})
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1:443", cfg.Host)
+	assert.True(t, cfg.Insecure)
+
+	cfg, err = CreateRestConfig(APIConfig{
+		AuthType: AuthTypeTLS,
+		TLS:      TLSConfig{CertFile: "client.crt", KeyFile: "client.key"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1:443", cfg.Host)
+	assert.False(t, cfg.Insecure)
+	assert.Equal(t, serviceAccountCAFile, cfg.TLSClientConfig.CAFile)
+	assert.Equal(t, "client.crt", cfg.TLSClientConfig.CertFile)
+	assert.Equal(t, "client.key", cfg.TLSClientConfig.KeyFile)
+}
+
+func TestCreateRestConfig_MissingServiceHost(t *testing.T) {
+	defer setEnv(t, "KUBERNETES_SERVICE_HOST", "")()
+
+	_, err := CreateRestConfig(APIConfig{AuthType: AuthTypeNone})
+	assert.Error(t, err)
+}
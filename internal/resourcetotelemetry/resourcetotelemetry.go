@@ -0,0 +1,143 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcetotelemetry implements the resource_to_telemetry_conversion
+// option shared by metric exporters. When enabled the labels of the resources
+// associated to each metric are copied to the labels of its time series, so
+// backends with a flat label model get the full context of the data.
+package resourcetotelemetry
+
+import (
+	"context"
+	"sort"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/exporter"
+)
+
+// Settings defines configuration for converting resource labels to metric
+// labels.
+type Settings struct {
+	// Enabled indicates whether to convert resource labels to metric labels.
+	Enabled bool `mapstructure:"enabled"`
+}
+
+type wrapperMetricsExporter struct {
+	exporter.MetricsExporter
+}
+
+func (wme *wrapperMetricsExporter) ConsumeMetricsData(ctx context.Context, md consumerdata.MetricsData) error {
+	return wme.MetricsExporter.ConsumeMetricsData(ctx, ConvertMetricsData(md))
+}
+
+// WrapMetricsExporter wraps the given exporter so resource labels are
+// converted to metric labels before the data reaches it. If the conversion is
+// not enabled the exporter is returned unchanged.
+func WrapMetricsExporter(set Settings, exp exporter.MetricsExporter) exporter.MetricsExporter {
+	if !set.Enabled {
+		return exp
+	}
+	return &wrapperMetricsExporter{MetricsExporter: exp}
+}
+
+// ConvertMetricsData returns a copy of md in which the labels of the resources
+// that apply to each metric, the one of the batch and its own, are added as
+// labels of its time series. Labels already defined by the metric take
+// precedence over the resource ones, and the labels of its own resource over
+// the batch ones. The resources are left untouched and the original data is
+// not modified.
+func ConvertMetricsData(md consumerdata.MetricsData) consumerdata.MetricsData {
+	if len(md.Metrics) == 0 {
+		return md
+	}
+
+	batchLabels := md.Resource.GetLabels()
+	metrics := make([]*metricspb.Metric, len(md.Metrics))
+	for i, metric := range md.Metrics {
+		metrics[i] = addLabels(metric, mergeLabels(batchLabels, metric.GetResource().GetLabels()))
+	}
+	md.Metrics = metrics
+	return md
+}
+
+// mergeLabels returns the batch labels overridden by the metric ones.
+func mergeLabels(batchLabels, metricLabels map[string]string) map[string]string {
+	if len(metricLabels) == 0 {
+		return batchLabels
+	}
+	if len(batchLabels) == 0 {
+		return metricLabels
+	}
+	labels := make(map[string]string, len(batchLabels)+len(metricLabels))
+	for k, v := range batchLabels {
+		labels[k] = v
+	}
+	for k, v := range metricLabels {
+		labels[k] = v
+	}
+	return labels
+}
+
+func addLabels(metric *metricspb.Metric, labels map[string]string) *metricspb.Metric {
+	if len(labels) == 0 || metric.GetMetricDescriptor() == nil {
+		return metric
+	}
+
+	existing := make(map[string]struct{}, len(metric.MetricDescriptor.LabelKeys))
+	for _, labelKey := range metric.MetricDescriptor.LabelKeys {
+		existing[labelKey.GetKey()] = struct{}{}
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		if _, ok := existing[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return metric
+	}
+	// Sort the keys so the order of the labels is deterministic.
+	sort.Strings(keys)
+
+	descriptor := *metric.MetricDescriptor
+	descriptor.LabelKeys = make([]*metricspb.LabelKey, 0, len(metric.MetricDescriptor.LabelKeys)+len(keys))
+	descriptor.LabelKeys = append(descriptor.LabelKeys, metric.MetricDescriptor.LabelKeys...)
+	for _, key := range keys {
+		descriptor.LabelKeys = append(descriptor.LabelKeys, &metricspb.LabelKey{Key: key})
+	}
+
+	timeseries := make([]*metricspb.TimeSeries, len(metric.Timeseries))
+	for i, series := range metric.Timeseries {
+		if series == nil {
+			continue
+		}
+		newSeries := *series
+		newSeries.LabelValues = make([]*metricspb.LabelValue, 0, len(series.LabelValues)+len(keys))
+		newSeries.LabelValues = append(newSeries.LabelValues, series.LabelValues...)
+		for _, key := range keys {
+			newSeries.LabelValues = append(newSeries.LabelValues, &metricspb.LabelValue{
+				Value:    labels[key],
+				HasValue: true,
+			})
+		}
+		timeseries[i] = &newSeries
+	}
+
+	return &metricspb.Metric{
+		MetricDescriptor: &descriptor,
+		Resource:         metric.Resource,
+		Timeseries:       timeseries,
+	}
+}
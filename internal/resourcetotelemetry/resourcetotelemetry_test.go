@@ -0,0 +1,119 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcetotelemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/exporter/exportertest"
+	"github.com/open-telemetry/opentelemetry-collector/testutils/metricstestutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertMetricsData(t *testing.T) {
+	ts := time.Now()
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Type:   "host",
+			Labels: map[string]string{"host.name": "h0", "k0": "resource"},
+		},
+		Metrics: []*metricspb.Metric{
+			metricstestutils.Gauge(
+				"gauge",
+				[]string{"k0"},
+				metricstestutils.Timeseries(ts, []string{"v0"}, metricstestutils.Double(ts, 1))),
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{Name: "with_resource"},
+				Resource: &resourcepb.Resource{
+					Labels: map[string]string{"container.id": "c0", "host.name": "h1"},
+				},
+				Timeseries: []*metricspb.TimeSeries{
+					metricstestutils.Timeseries(ts, nil, metricstestutils.Double(ts, 2)),
+				},
+			},
+		},
+	}
+
+	got := ConvertMetricsData(md)
+	require.Len(t, got.Metrics, 2)
+	assert.Equal(t, md.Resource, got.Resource)
+
+	gauge := got.Metrics[0]
+	assert.Equal(t,
+		[]*metricspb.LabelKey{{Key: "k0"}, {Key: "host.name"}},
+		gauge.MetricDescriptor.LabelKeys)
+	assert.Equal(t,
+		[]*metricspb.LabelValue{{Value: "v0", HasValue: true}, {Value: "h0", HasValue: true}},
+		gauge.Timeseries[0].LabelValues)
+	assert.Equal(t, md.Metrics[0].Timeseries[0].Points, gauge.Timeseries[0].Points)
+
+	// The labels of the batch resource are merged with the ones of the
+	// metric resource, which take precedence.
+	withResource := got.Metrics[1]
+	assert.Equal(t,
+		[]*metricspb.LabelKey{{Key: "container.id"}, {Key: "host.name"}, {Key: "k0"}},
+		withResource.MetricDescriptor.LabelKeys)
+	assert.Equal(t,
+		[]*metricspb.LabelValue{
+			{Value: "c0", HasValue: true},
+			{Value: "h1", HasValue: true},
+			{Value: "resource", HasValue: true},
+		},
+		withResource.Timeseries[0].LabelValues)
+
+	// The original data must not be modified.
+	assert.Len(t, md.Metrics[0].MetricDescriptor.LabelKeys, 1)
+	assert.Len(t, md.Metrics[0].Timeseries[0].LabelValues, 1)
+	assert.Len(t, md.Metrics[1].MetricDescriptor.LabelKeys, 0)
+}
+
+func TestConvertMetricsDataWithoutResource(t *testing.T) {
+	md := consumerdata.MetricsData{
+		Metrics: []*metricspb.Metric{
+			{MetricDescriptor: &metricspb.MetricDescriptor{Name: "m0"}},
+			nil,
+		},
+	}
+	got := ConvertMetricsData(md)
+	assert.Equal(t, md.Metrics, got.Metrics)
+}
+
+func TestWrapMetricsExporter(t *testing.T) {
+	sink := new(exportertest.SinkMetricsExporter)
+	assert.Equal(t, sink, WrapMetricsExporter(Settings{}, sink))
+
+	wrapped := WrapMetricsExporter(Settings{Enabled: true}, sink)
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Labels: map[string]string{"k0": "v0"},
+		},
+		Metrics: []*metricspb.Metric{
+			{MetricDescriptor: &metricspb.MetricDescriptor{Name: "m0"}},
+		},
+	}
+	require.NoError(t, wrapped.ConsumeMetricsData(context.Background(), md))
+
+	got := sink.AllMetrics()
+	require.Len(t, got, 1)
+	assert.Equal(t,
+		[]*metricspb.LabelKey{{Key: "k0"}},
+		got[0].Metrics[0].MetricDescriptor.LabelKeys)
+}
@@ -0,0 +1,12 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator
+
+go 1.12
+
+require (
+	github.com/census-instrumentation/opencensus-proto v0.2.1
+	github.com/golang/protobuf v1.3.2
+	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/signalfx/com_signalfx_metrics_protobuf v0.0.0-20190530013331-054be550cb49
+	github.com/stretchr/testify v1.4.0
+	go.uber.org/zap v1.12.0
+)
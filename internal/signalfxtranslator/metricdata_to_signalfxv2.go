@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package signalfxexporter
+package signalfxtranslator
 
 import (
 	"fmt"
@@ -76,7 +76,10 @@ var (
 	infinityBoundSFxDimValue = float64ToDimValue(math.Inf(1))
 )
 
-func metricDataToSingalFxV2(
+// MetricDataToSignalFxV2 converts consumerdata.MetricsData to SignalFx proto
+// data points. Returning the converted data points and the number of dropped
+// time series.
+func MetricDataToSignalFxV2(
 	logger *zap.Logger,
 	md consumerdata.MetricsData,
 ) (sfxDataPoints []*sfxpb.DataPoint, numDroppedTimeSeries int, err error) {
@@ -0,0 +1,122 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxtranslator
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator/sfxtest"
+)
+
+// randomDataPoints generates data points of all the types that can be
+// converted back and forth without loss: gauges and cumulative counters with
+// integer or double values, millisecond timestamps and dimensions.
+func randomDataPoints(rnd *rand.Rand, n int) []*sfxpb.DataPoint {
+	metricTypes := []sfxpb.MetricType{sfxpb.MetricType_GAUGE, sfxpb.MetricType_CUMULATIVE_COUNTER}
+	dps := make([]*sfxpb.DataPoint, 0, n)
+	for i := 0; i < n; i++ {
+		datum := &sfxpb.Datum{}
+		if rnd.Intn(2) == 0 {
+			datum.IntValue = sfxtest.Int64Ptr(rnd.Int63())
+		} else {
+			datum.DoubleValue = sfxtest.Float64Ptr(rnd.NormFloat64() * 1e6)
+		}
+		dps = append(dps, &sfxpb.DataPoint{
+			Metric:     sfxtest.StrPtr(fmt.Sprintf("metric_%d", i)),
+			Timestamp:  sfxtest.Int64Ptr(1e12 + rnd.Int63n(1e12)),
+			Value:      datum,
+			MetricType: sfxtest.SFxTypePtr(metricTypes[rnd.Intn(len(metricTypes))]),
+			Dimensions: sfxtest.BuildNDimensions(uint(rnd.Intn(5))),
+		})
+	}
+	return dps
+}
+
+func TestRoundTrip_SignalFx(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		want := randomDataPoints(rnd, 10)
+
+		md, numDropped := SignalFxV2ToMetricsData(zap.NewNop(), want)
+		require.Equal(t, 0, numDropped)
+
+		got, numDropped, err := MetricDataToSignalFxV2(zap.NewNop(), *md)
+		require.NoError(t, err)
+		require.Equal(t, 0, numDropped)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRoundTrip_MetricsData(t *testing.T) {
+	// The points are built from SignalFx timestamps so they are exact in
+	// milliseconds, SignalFx has no description, unit or start timestamp.
+	rnd := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		want, _ := SignalFxV2ToMetricsData(zap.NewNop(), randomDataPoints(rnd, 10))
+
+		dps, numDropped, err := MetricDataToSignalFxV2(zap.NewNop(), *want)
+		require.NoError(t, err)
+		require.Equal(t, 0, numDropped)
+
+		got, numDropped := SignalFxV2ToMetricsData(zap.NewNop(), dps)
+		require.Equal(t, 0, numDropped)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRoundTrip_MetricTypes(t *testing.T) {
+	tests := []struct {
+		ocType  metricspb.MetricDescriptor_Type
+		sfxType sfxpb.MetricType
+	}{
+		{metricspb.MetricDescriptor_GAUGE_INT64, sfxpb.MetricType_GAUGE},
+		{metricspb.MetricDescriptor_GAUGE_DOUBLE, sfxpb.MetricType_GAUGE},
+		{metricspb.MetricDescriptor_CUMULATIVE_INT64, sfxpb.MetricType_CUMULATIVE_COUNTER},
+		{metricspb.MetricDescriptor_CUMULATIVE_DOUBLE, sfxpb.MetricType_CUMULATIVE_COUNTER},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ocType.String(), func(t *testing.T) {
+			point := &metricspb.Point{Value: &metricspb.Point_Int64Value{Int64Value: 7}}
+			if tt.ocType == metricspb.MetricDescriptor_GAUGE_DOUBLE ||
+				tt.ocType == metricspb.MetricDescriptor_CUMULATIVE_DOUBLE {
+				point.Value = &metricspb.Point_DoubleValue{DoubleValue: 7.5}
+			}
+			md := consumerdata.MetricsData{
+				Metrics: []*metricspb.Metric{{
+					MetricDescriptor: &metricspb.MetricDescriptor{Name: "m", Type: tt.ocType},
+					Timeseries:       []*metricspb.TimeSeries{{Points: []*metricspb.Point{point}}},
+				}},
+			}
+
+			dps, _, err := MetricDataToSignalFxV2(zap.NewNop(), md)
+			require.NoError(t, err)
+			require.Len(t, dps, 1)
+			assert.Equal(t, tt.sfxType, dps[0].GetMetricType())
+
+			got, _ := SignalFxV2ToMetricsData(zap.NewNop(), dps)
+			require.Len(t, got.Metrics, 1)
+			assert.Equal(t, tt.ocType, got.Metrics[0].MetricDescriptor.Type)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sfxtest contains helpers to build SignalFx data points in tests.
+package sfxtest
+
+import (
+	"strconv"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf"
+)
+
+// StrPtr returns a pointer to a copy of s.
+func StrPtr(s string) *string {
+	l := s
+	return &l
+}
+
+// Int64Ptr returns a pointer to a copy of i.
+func Int64Ptr(i int64) *int64 {
+	l := i
+	return &l
+}
+
+// Float64Ptr returns a pointer to a copy of f.
+func Float64Ptr(f float64) *float64 {
+	l := f
+	return &l
+}
+
+// SFxTypePtr returns a pointer to a copy of t.
+func SFxTypePtr(t sfxpb.MetricType) *sfxpb.MetricType {
+	l := t
+	return &l
+}
+
+// BuildNDimensions returns n dimensions with keys "k0", "k1", ... and values
+// "v0", "v1", ...
+func BuildNDimensions(n uint) []*sfxpb.Dimension {
+	d := make([]*sfxpb.Dimension, 0, n)
+	for i := uint(0); i < n; i++ {
+		idx := int(i)
+		suffix := strconv.Itoa(idx)
+		d = append(d, &sfxpb.Dimension{
+			Key:   StrPtr("k" + suffix),
+			Value: StrPtr("v" + suffix),
+		})
+	}
+	return d
+}
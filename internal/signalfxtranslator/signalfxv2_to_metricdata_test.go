@@ -12,10 +12,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package signalfxreceiver
+package signalfxtranslator
 
 import (
-	"strconv"
 	"testing"
 	"time"
 
@@ -25,6 +24,8 @@ import (
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator/sfxtest"
 )
 
 func Test_signalFxV2ToMetricsData(t *testing.T) {
@@ -33,13 +34,13 @@ func Test_signalFxV2ToMetricsData(t *testing.T) {
 
 	buildDefaulstSFxDataPt := func() *sfxpb.DataPoint {
 		return &sfxpb.DataPoint{
-			Metric:    strPtr("single"),
+			Metric:    sfxtest.StrPtr("single"),
 			Timestamp: &msec,
 			Value: &sfxpb.Datum{
-				IntValue: int64Ptr(13),
+				IntValue: sfxtest.Int64Ptr(13),
 			},
-			MetricType: sfxTypePtr(sfxpb.MetricType_GAUGE),
-			Dimensions: buildNDimensions(3),
+			MetricType: sfxtest.SFxTypePtr(sfxpb.MetricType_GAUGE),
+			Dimensions: sfxtest.BuildNDimensions(3),
 		}
 	}
 
@@ -97,9 +98,9 @@ func Test_signalFxV2ToMetricsData(t *testing.T) {
 			name: "double_gauge",
 			sfxDataPoints: func() []*sfxpb.DataPoint {
 				pt := buildDefaulstSFxDataPt()
-				pt.MetricType = sfxTypePtr(sfxpb.MetricType_GAUGE)
+				pt.MetricType = sfxtest.SFxTypePtr(sfxpb.MetricType_GAUGE)
 				pt.Value = &sfxpb.Datum{
-					DoubleValue: float64Ptr(13.13),
+					DoubleValue: sfxtest.Float64Ptr(13.13),
 				}
 				return []*sfxpb.DataPoint{pt}
 			}(),
@@ -114,7 +115,7 @@ func Test_signalFxV2ToMetricsData(t *testing.T) {
 			name: "int_counter",
 			sfxDataPoints: func() []*sfxpb.DataPoint {
 				pt := buildDefaulstSFxDataPt()
-				pt.MetricType = sfxTypePtr(sfxpb.MetricType_COUNTER)
+				pt.MetricType = sfxtest.SFxTypePtr(sfxpb.MetricType_COUNTER)
 				return []*sfxpb.DataPoint{pt}
 			}(),
 			wantMetricsData: func() *consumerdata.MetricsData {
@@ -127,9 +128,9 @@ func Test_signalFxV2ToMetricsData(t *testing.T) {
 			name: "double_counter",
 			sfxDataPoints: func() []*sfxpb.DataPoint {
 				pt := buildDefaulstSFxDataPt()
-				pt.MetricType = sfxTypePtr(sfxpb.MetricType_COUNTER)
+				pt.MetricType = sfxtest.SFxTypePtr(sfxpb.MetricType_COUNTER)
 				pt.Value = &sfxpb.Datum{
-					DoubleValue: float64Ptr(13.13),
+					DoubleValue: sfxtest.Float64Ptr(13.13),
 				}
 				return []*sfxpb.DataPoint{pt}
 			}(),
@@ -199,11 +200,11 @@ func Test_signalFxV2ToMetricsData(t *testing.T) {
 
 				// Non-supported type
 				pt2 := buildDefaulstSFxDataPt()
-				pt2.MetricType = sfxTypePtr(sfxpb.MetricType_ENUM)
+				pt2.MetricType = sfxtest.SFxTypePtr(sfxpb.MetricType_ENUM)
 
 				// Unknown type
 				pt3 := buildDefaulstSFxDataPt()
-				pt3.MetricType = sfxTypePtr(sfxpb.MetricType_CUMULATIVE_COUNTER + 1)
+				pt3.MetricType = sfxtest.SFxTypePtr(sfxpb.MetricType_CUMULATIVE_COUNTER + 1)
 
 				return []*sfxpb.DataPoint{
 					pt0, buildDefaulstSFxDataPt(), pt1, pt2, pt3}
@@ -245,7 +246,7 @@ func Test_buildPoint_errors(t *testing.T) {
 			args: args{
 				sfxDataPoint: &sfxpb.DataPoint{
 					Value: &sfxpb.Datum{
-						IntValue: int64Ptr(13),
+						IntValue: sfxtest.Int64Ptr(13),
 					},
 				},
 				expectedMetricType: metricspb.MetricDescriptor_CUMULATIVE_DOUBLE,
@@ -257,7 +258,7 @@ func Test_buildPoint_errors(t *testing.T) {
 			args: args{
 				sfxDataPoint: &sfxpb.DataPoint{
 					Value: &sfxpb.Datum{
-						DoubleValue: float64Ptr(13.13),
+						DoubleValue: sfxtest.Float64Ptr(13.13),
 					},
 				},
 				expectedMetricType: metricspb.MetricDescriptor_GAUGE_INT64,
@@ -278,7 +279,7 @@ func Test_buildPoint_errors(t *testing.T) {
 			args: args{
 				sfxDataPoint: &sfxpb.DataPoint{
 					Value: &sfxpb.Datum{
-						StrValue: strPtr("13.13"),
+						StrValue: sfxtest.StrPtr("13.13"),
 					},
 				},
 			},
@@ -288,7 +289,7 @@ func Test_buildPoint_errors(t *testing.T) {
 			name: "dbl_as_str",
 			args: args{
 				sfxDataPoint: &sfxpb.DataPoint{
-					Value: &sfxpb.Datum{StrValue: strPtr("13.13")},
+					Value: &sfxpb.Datum{StrValue: sfxtest.StrPtr("13.13")},
 				},
 				expectedMetricType: metricspb.MetricDescriptor_GAUGE_DOUBLE,
 			},
@@ -300,7 +301,7 @@ func Test_buildPoint_errors(t *testing.T) {
 			name: "str_not_dbl",
 			args: args{
 				sfxDataPoint: &sfxpb.DataPoint{
-					Value: &sfxpb.Datum{StrValue: strPtr("not_a_number")},
+					Value: &sfxpb.Datum{StrValue: sfxtest.StrPtr("not_a_number")},
 				},
 				expectedMetricType: metricspb.MetricDescriptor_GAUGE_DOUBLE,
 			},
@@ -315,36 +316,3 @@ func Test_buildPoint_errors(t *testing.T) {
 		})
 	}
 }
-
-func strPtr(s string) *string {
-	l := s
-	return &l
-}
-
-func int64Ptr(i int64) *int64 {
-	l := i
-	return &l
-}
-
-func float64Ptr(f float64) *float64 {
-	l := f
-	return &l
-}
-
-func sfxTypePtr(t sfxpb.MetricType) *sfxpb.MetricType {
-	l := t
-	return &l
-}
-
-func buildNDimensions(n uint) []*sfxpb.Dimension {
-	d := make([]*sfxpb.Dimension, 0, n)
-	for i := uint(0); i < n; i++ {
-		idx := int(i)
-		suffix := strconv.Itoa(idx)
-		d = append(d, &sfxpb.Dimension{
-			Key:   strPtr("k" + suffix),
-			Value: strPtr("v" + suffix),
-		})
-	}
-	return d
-}
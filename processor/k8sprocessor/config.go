@@ -16,6 +16,8 @@ package k8sprocessor
 
 import (
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 // Config defines configuration for k8s attributes processor.
@@ -28,6 +30,9 @@ type Config struct {
 	// directly from services to be able to correctly detect the pod IPs.
 	Passthrough bool `mapstructure:"passthrough"`
 
+	// APIConfig defines how to connect and authenticate to the K8s API.
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
 	// Extract section allows specifying extraction rules to extract
 	// data from k8s pod specs
 	Extract ExtractConfig `mapstructure:"extract"`
@@ -23,6 +23,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -50,6 +52,7 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "k8s_tagger",
 				NameVal: "k8s_tagger",
 			},
+			APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
 		})
 
 	p1 := config.Processors["k8s_tagger/2"]
@@ -60,6 +63,11 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "k8s_tagger/2",
 			},
 			Passthrough: false,
+			APIConfig: k8sconfig.APIConfig{
+				AuthType: k8sconfig.AuthTypeKubeConfig,
+				QPS:      20,
+				Burst:    40,
+			},
 			Extract: ExtractConfig{
 				Metadata: []string{"podName", "deployment", "cluster", "namespace", "node", "startTime"},
 				Annotations: []FieldExtractConfig{
@@ -21,6 +21,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/processor"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/kube"
 )
 
@@ -47,6 +48,7 @@ func (f *Factory) CreateDefaultConfig() configmodels.Processor {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
 	}
 }
 
@@ -61,6 +63,7 @@ func (f *Factory) CreateTraceProcessor(
 	if oCfg.Passthrough {
 		opts = append(opts, WithPassthrough())
 	}
+	opts = append(opts, WithAPIConfig(oCfg.APIConfig))
 	// extraction rules
 	opts = append(opts, WithExtractMetadata(oCfg.Extract.Metadata...))
 	opts = append(opts, WithExtractLabels(oCfg.Extract.Labels...))
@@ -5,6 +5,7 @@ go 1.13
 require (
 	github.com/census-instrumentation/opencensus-proto v0.2.1
 	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig v0.0.0
 	github.com/stretchr/testify v1.4.0
 	go.opencensus.io v0.22.2
 	go.uber.org/zap v1.13.0
@@ -12,3 +13,5 @@ require (
 	k8s.io/apimachinery v0.17.0
 	k8s.io/client-go v12.0.0+incompatible
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig => ../../internal/k8sconfig
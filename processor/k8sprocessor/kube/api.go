@@ -16,13 +16,10 @@ package kube
 
 import (
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
-func newAPIClientset() (*kubernetes.Clientset, error) {
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		return nil, err
-	}
-	return kubernetes.NewForConfig(config)
+func newAPIClientset(apiCfg k8sconfig.APIConfig) (*kubernetes.Clientset, error) {
+	return k8sconfig.MakeClient(apiCfg)
 }
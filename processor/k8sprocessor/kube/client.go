@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/observability"
 )
 
@@ -49,7 +50,7 @@ type WatchClient struct {
 }
 
 // New initializes a new k8s Client.
-func New(logger *zap.Logger, rules ExtractionRules, filters Filters, newClientSet APIClientsetProvider, newInformer InformerProvider) (Client, error) {
+func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, newClientSet APIClientsetProvider, newInformer InformerProvider) (Client, error) {
 
 	// Extract deployment name from the pod name. Pod name is created using
 	// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
@@ -65,7 +66,7 @@ func New(logger *zap.Logger, rules ExtractionRules, filters Filters, newClientSe
 		newClientSet = newAPIClientset
 	}
 
-	kc, err := newClientSet()
+	kc, err := newClientSet(apiCfg)
 	if err != nil {
 		return nil, err
 	}
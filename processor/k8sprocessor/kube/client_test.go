@@ -363,7 +363,7 @@ func TestPodIgnorePatterns(t *testing.T) {
 }
 
 func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters) *WatchClient {
-	c, err := New(zap.NewNop(), e, f, newFakeAPIClientset, newFakeInformer)
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, e, f, newFakeAPIClientset, newFakeInformer)
 	require.NoError(t, err)
 	return c.(*WatchClient)
 }
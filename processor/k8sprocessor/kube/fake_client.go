@@ -17,6 +17,8 @@ package kube
 import (
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 // FakeClient is used as a replacement for WatchClient in test cases.
@@ -27,7 +29,7 @@ type FakeClient struct {
 }
 
 // NewFakeClient instantiates a new FakeClient object and satisfies the ClientProvider type
-func NewFakeClient(logger *zap.Logger, rules ExtractionRules, filters Filters, newClientSet APIClientsetProvider, newInformer InformerProvider) (Client, error) {
+func NewFakeClient(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, newClientSet APIClientsetProvider, newInformer InformerProvider) (Client, error) {
 	return &FakeClient{map[string]*Pod{}, rules, filters}, nil
 }
 
@@ -43,6 +45,6 @@ func (f *FakeClient) Start() {}
 // Stop is a noop for FakeClient.
 func (f *FakeClient) Stop() {}
 
-func newFakeAPIClientset() (*kubernetes.Clientset, error) {
+func newFakeAPIClientset(_ k8sconfig.APIConfig) (*kubernetes.Clientset, error) {
 	return &kubernetes.Clientset{}, nil
 }
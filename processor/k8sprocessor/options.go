@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/selection"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/kube"
 )
 
@@ -51,6 +52,14 @@ func WithPassthrough() Option {
 	}
 }
 
+// WithAPIConfig provides k8s API related configuration to the processor.
+func WithAPIConfig(cfg k8sconfig.APIConfig) Option {
+	return func(p *kubernetesprocessor) error {
+		p.apiConfig = cfg
+		return p.apiConfig.Validate()
+	}
+}
+
 // WithExtractMetadata allows specifying options to control extraction of pod metadata.
 func WithExtractMetadata(fields ...string) Option {
 	return func(p *kubernetesprocessor) error {
@@ -25,6 +25,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/processor"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/kube"
 )
 
@@ -38,6 +39,7 @@ type kubernetesprocessor struct {
 	nextConsumer    consumer.TraceConsumer
 	kc              kube.Client
 	passthroughMode bool
+	apiConfig       k8sconfig.APIConfig
 	rules           kube.ExtractionRules
 	filters         kube.Filters
 }
@@ -61,7 +63,7 @@ func NewTraceProcessor(
 		kubeClient = kube.New
 	}
 	if !kp.passthroughMode {
-		kc, err := kubeClient(logger, kp.rules, kp.filters, nil, nil)
+		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/kube"
 )
 
@@ -39,6 +40,16 @@ func TestNewTraceProcessor(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNewTraceProcessor_InvalidAPIConfig(t *testing.T) {
+	_, err := NewTraceProcessor(
+		zap.NewNop(),
+		exportertest.NewNopTraceExporter(),
+		kube.NewFakeClient,
+		WithAPIConfig(k8sconfig.APIConfig{AuthType: "invalid"}),
+	)
+	assert.Error(t, err)
+}
+
 func TestIPDetection(t *testing.T) {
 	next := &testConsumer{}
 	kp, err := NewTraceProcessor(
@@ -64,7 +64,11 @@ func (r *collectDRecord) protoTime() *timestamp.Timestamp {
 	return tsp
 }
 
-func (r *collectDRecord) appendToMetrics(metrics []*metricspb.Metric, defaultLabels map[string]string) ([]*metricspb.Metric, error) {
+func (r *collectDRecord) appendToMetrics(
+	metrics []*metricspb.Metric,
+	defaultLabels map[string]string,
+	labelNames map[string]string,
+) ([]*metricspb.Metric, error) {
 	// Ignore if record is an event instead of data point
 	if r.isEvent() {
 		recordEventsReceived()
@@ -89,7 +93,7 @@ func (r *collectDRecord) appendToMetrics(metrics []*metricspb.Metric, defaultLab
 				addIfNotNullOrEmpty(labels, "dsname", dsName)
 			}
 
-			metric, err := r.newMetric(metricName, dsType, val, labels)
+			metric, err := r.newMetric(metricName, dsType, val, renameLabels(labels, labelNames))
 			if err != nil {
 				return metrics, fmt.Errorf("error processing metric %s: %v", metricName, err)
 			}
@@ -278,6 +282,25 @@ func parseNameForLabels(labels map[string]string, key string, val *string) {
 	addIfNotNullOrEmpty(labels, key, &instanceName)
 }
 
+// renameLabels returns the labels with their keys renamed according to
+// labelNames. Labels renamed to an empty string are dropped.
+func renameLabels(labels map[string]string, labelNames map[string]string) map[string]string {
+	if len(labelNames) == 0 {
+		return labels
+	}
+	renamed := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if newName, ok := labelNames[k]; ok {
+			if newName == "" {
+				continue
+			}
+			k = newName
+		}
+		renamed[k] = v
+	}
+	return renamed
+}
+
 func labelKeysAndValues(labels map[string]string) ([]*metricspb.LabelKey, []*metricspb.LabelValue) {
 	keys := make([]*metricspb.LabelKey, len(labels))
 	values := make([]*metricspb.LabelValue, len(labels))
@@ -37,7 +37,7 @@ func TestDecodeEvent(t *testing.T) {
 	require.NoError(t, err)
 
 	for _, r := range records {
-		m2, err := r.appendToMetrics(m1, map[string]string{})
+		m2, err := r.appendToMetrics(m1, map[string]string{}, nil)
 		assert.NoError(t, err)
 		assert.Len(t, m2, 0)
 	}
@@ -65,7 +65,7 @@ func TestDecodeMetrics(t *testing.T) {
 	require.NoError(t, err)
 
 	for _, r := range records {
-		metrics, err = r.appendToMetrics(metrics, map[string]string{})
+		metrics, err = r.appendToMetrics(metrics, map[string]string{}, nil)
 		assert.NoError(t, err)
 	}
 	assert.Equal(t, 10, len(metrics))
@@ -384,3 +384,23 @@ var wantMetricsData = []*metricspb.Metric{
 		},
 	},
 }
+
+func TestRenameLabels(t *testing.T) {
+	labels := map[string]string{
+		"plugin":          "cpu",
+		"plugin_instance": "0",
+		"dsname":          "value",
+	}
+
+	assert.Equal(t, labels, renameLabels(labels, nil))
+
+	got := renameLabels(labels, map[string]string{
+		"plugin_instance": "instance",
+		"dsname":          "",
+		"missing":         "other",
+	})
+	assert.Equal(t, map[string]string{
+		"plugin":   "cpu",
+		"instance": "0",
+	}, got)
+}
@@ -15,16 +15,38 @@
 package collectdreceiver
 
 import (
-	"time"
-
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
 )
 
 // Config defines configuration for Collectd receiver.
 type Config struct {
 	configmodels.ReceiverSettings `mapstructure:",squash"`
 
-	Timeout          time.Duration `mapstructure:"timeout"`
-	AttributesPrefix string        `mapstructure:"attributes_prefix"`
-	Encoding         string        `mapstructure:"encoding"`
+	// Settings holds the TLS, CORS, authentication, request size and timeout
+	// settings of the server.
+	httpserver.Settings `mapstructure:",squash"`
+
+	// RecordSettings controls which records are accepted and how they are
+	// converted to metrics.
+	RecordSettings `mapstructure:",squash"`
+
+	AttributesPrefix string `mapstructure:"attributes_prefix"`
+	Encoding         string `mapstructure:"encoding"`
+}
+
+// RecordSettings defines how the collectd records received are filtered and
+// converted to metrics.
+type RecordSettings struct {
+	// IncludePlugins is the list of collectd plugins whose data is accepted. If
+	// empty the data of all plugins is accepted.
+	IncludePlugins []string `mapstructure:"include_plugins"`
+
+	// ExcludePlugins is the list of collectd plugins whose data is dropped.
+	ExcludePlugins []string `mapstructure:"exclude_plugins"`
+
+	// LabelNames renames the labels of the metrics, eg.: "plugin_instance" can
+	// be renamed to "instance". A label renamed to an empty string is dropped.
+	LabelNames map[string]string `mapstructure:"label_names"`
 }
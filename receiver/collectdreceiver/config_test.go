@@ -23,6 +23,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -51,7 +53,18 @@ func TestLoadConfig(t *testing.T) {
 				NameVal:  "collectd/one",
 				Endpoint: "localhost:12345",
 			},
-			Timeout:          time.Second * 50,
+			RecordSettings: RecordSettings{
+				IncludePlugins: []string{"memory", "cpu", "df"},
+				ExcludePlugins: []string{"df"},
+				LabelNames: map[string]string{
+					"plugin_instance": "instance",
+					"dsname":          "",
+				},
+			},
+			Settings: httpserver.Settings{
+				ReadTimeout:  time.Second * 50,
+				WriteTimeout: time.Second * 10,
+			},
 			AttributesPrefix: "dap_",
 			Encoding:         "command",
 		})
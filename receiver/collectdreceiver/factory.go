@@ -25,6 +25,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/consumer"
 	"github.com/open-telemetry/opentelemetry-collector/receiver"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
 )
 
 // This file implements factory for CollectD receiver.
@@ -60,7 +62,10 @@ func (f *Factory) CreateDefaultConfig() configmodels.Receiver {
 			NameVal:  typeStr,
 			Endpoint: defaultBindEndpoint,
 		},
-		Timeout:  defaultTimeout,
+		Settings: httpserver.Settings{
+			ReadTimeout:  defaultTimeout,
+			WriteTimeout: defaultTimeout,
+		},
 		Encoding: defaultEncodingFormat,
 	}
 }
@@ -91,5 +96,12 @@ func (f *Factory) CreateMetricsReceiver(
 			c.Encoding,
 		)
 	}
-	return New(logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	return New(
+		c.Name(),
+		logger,
+		c.Endpoint,
+		c.AttributesPrefix,
+		c.Settings,
+		c.RecordSettings,
+		nextConsumer)
 }
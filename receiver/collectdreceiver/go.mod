@@ -6,7 +6,12 @@ require (
 	github.com/census-instrumentation/opencensus-proto v0.2.1
 	github.com/golang/protobuf v1.3.2
 	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver v0.0.0
 	github.com/stretchr/testify v1.4.0
 	go.opencensus.io v0.22.1
 	go.uber.org/zap v1.10.0
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver => ../../internal/httpserver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../../extension/auth
@@ -17,8 +17,10 @@ package collectdreceiver
 import (
 	"context"
 
+	"github.com/open-telemetry/opentelemetry-collector/observability"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 )
 
 func init() {
@@ -28,6 +30,7 @@ func init() {
 		viewMetricsReceived,
 		viewEventsReceived,
 		viewBlankDefaultAttrs,
+		viewRecordsFiltered,
 	)
 }
 
@@ -37,6 +40,7 @@ var (
 	mMetricsReceived   = stats.Int64("otelcol/collectd/metrics_received", "Number of metrics received", "1")
 	mEventsReceived    = stats.Int64("otelcol/collectd/events_received", "Number of events received", "1")
 	mBlankDefaultAttrs = stats.Int64("otelcol/collectd/blank_default_attrs", "Number of blank default attributes received", "1")
+	mRecordsFiltered   = stats.Int64("otelcol/collectd/records_filtered", "Number of records dropped by the plugin filters", "1")
 )
 
 var viewInvalidRequests = &view.View{
@@ -74,6 +78,14 @@ var viewBlankDefaultAttrs = &view.View{
 	Aggregation: view.Sum(),
 }
 
+var viewRecordsFiltered = &view.View{
+	Name:        mRecordsFiltered.Name(),
+	Description: mRecordsFiltered.Description(),
+	Measure:     mRecordsFiltered,
+	TagKeys:     []tag.Key{observability.TagKeyReceiver},
+	Aggregation: view.Sum(),
+}
+
 func recordRequestErrors() {
 	stats.Record(context.Background(), mErrors.M(int64(1)))
 }
@@ -93,3 +105,9 @@ func recordEventsReceived() {
 func recordDefaultBlankAttrs() {
 	stats.Record(context.Background(), mBlankDefaultAttrs.M(int64(1)))
 }
+
+// recordRecordsFiltered records a filtered record against the given context,
+// which carries the receiver name.
+func recordRecordsFiltered(ctx context.Context) {
+	stats.Record(ctx, mRecordsFiltered.M(int64(1)))
+}
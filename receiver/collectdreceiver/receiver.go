@@ -20,16 +20,19 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector/component"
 	"github.com/open-telemetry/opentelemetry-collector/consumer"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/observability"
 	"github.com/open-telemetry/opentelemetry-collector/receiver"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
 )
 
 var (
@@ -43,38 +46,49 @@ var _ receiver.MetricsReceiver = (*collectdReceiver)(nil)
 // collectdReceiver implements the receiver.MetricsReceiver for CollectD protocol.
 type collectdReceiver struct {
 	sync.Mutex
+	name               string
 	logger             *zap.Logger
 	addr               string
+	httpSettings       httpserver.Settings
 	server             *http.Server
 	defaultAttrsPrefix string
 	nextConsumer       consumer.MetricsConsumer
 
+	includePlugins map[string]struct{}
+	excludePlugins map[string]struct{}
+	labelNames     map[string]string
+
 	startOnce sync.Once
 	stopOnce  sync.Once
 }
 
 // New creates the CollectD receiver with the given parameters.
 func New(
+	name string,
 	logger *zap.Logger,
 	addr string,
-	timeout time.Duration,
 	defaultAttrsPrefix string,
+	httpSettings httpserver.Settings,
+	recordSettings RecordSettings,
 	nextConsumer consumer.MetricsConsumer) (receiver.MetricsReceiver, error) {
 	if nextConsumer == nil {
 		return nil, errNilNextConsumer
 	}
 
 	r := &collectdReceiver{
+		name:               name,
 		logger:             logger,
 		addr:               addr,
+		httpSettings:       httpSettings,
 		nextConsumer:       nextConsumer,
 		defaultAttrsPrefix: defaultAttrsPrefix,
+		includePlugins:     stringSet(recordSettings.IncludePlugins),
+		excludePlugins:     stringSet(recordSettings.ExcludePlugins),
+		labelNames:         recordSettings.LabelNames,
 	}
 	r.server = &http.Server{
-		Addr:         addr,
-		Handler:      r,
-		ReadTimeout:  timeout,
-		WriteTimeout: timeout,
+		ReadTimeout:  httpSettings.ReadTimeout,
+		WriteTimeout: httpSettings.WriteTimeout,
 	}
 	return r, nil
 }
@@ -93,10 +107,19 @@ func (cdr *collectdReceiver) Start(host component.Host) error {
 
 	err := errAlreadyStarted
 	cdr.startOnce.Do(func() {
-		err = nil
+		cdr.server.Handler, err = cdr.httpSettings.Handler(cdr)
+		if err != nil {
+			return
+		}
+
+		var ln net.Listener
+		ln, err = cdr.httpSettings.Listen(cdr.addr)
+		if err != nil {
+			err = fmt.Errorf("error starting collectd receiver: %v", err)
+			return
+		}
 		go func() {
-			err = cdr.server.ListenAndServe()
-			if err != nil {
+			if err := cdr.server.Serve(ln); err != nil && err != http.ErrServerClosed {
 				host.ReportFatalError(fmt.Errorf("error starting collectd receiver: %v", err))
 			}
 		}()
@@ -143,23 +166,62 @@ func (cdr *collectdReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defaultAttrs := cdr.defaultAttributes(r)
 
 	md := consumerdata.MetricsData{}
-	ctx := context.Background()
+	ctx := observability.ContextWithReceiverName(context.Background(), cdr.name)
 	for _, record := range records {
-		md.Metrics, err = record.appendToMetrics(md.Metrics, defaultAttrs)
+		if !cdr.acceptsPlugin(record.Plugin) {
+			recordRecordsFiltered(ctx)
+			continue
+		}
+		md.Metrics, err = record.appendToMetrics(md.Metrics, defaultAttrs, cdr.labelNames)
 		if err != nil {
 			cdr.handleHTTPErr(w, err, "unable to process metrics")
 			return
 		}
 	}
 
+	numTimeseries := 0
+	for _, metric := range md.Metrics {
+		numTimeseries += len(metric.Timeseries)
+	}
+
 	err = cdr.nextConsumer.ConsumeMetricsData(ctx, md)
 	if err != nil {
+		// In case of error assume that all time series were dropped.
+		observability.RecordMetricsForMetricsReceiver(ctx, numTimeseries, numTimeseries)
 		cdr.handleHTTPErr(w, err, "unable to process metrics")
 		return
 	}
+	observability.RecordMetricsForMetricsReceiver(ctx, numTimeseries, 0)
 	w.Write([]byte("OK"))
 }
 
+// acceptsPlugin returns true if the data of the given plugin passes the
+// include and exclude lists of the receiver.
+func (cdr *collectdReceiver) acceptsPlugin(plugin *string) bool {
+	var name string
+	if plugin != nil {
+		name = *plugin
+	}
+	if len(cdr.includePlugins) > 0 {
+		if _, ok := cdr.includePlugins[name]; !ok {
+			return false
+		}
+	}
+	_, excluded := cdr.excludePlugins[name]
+	return !excluded
+}
+
+func stringSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
 func (cdr *collectdReceiver) defaultAttributes(req *http.Request) map[string]string {
 	if cdr.defaultAttrsPrefix == "" {
 		return nil
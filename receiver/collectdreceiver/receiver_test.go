@@ -31,6 +31,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
 )
 
 type metricLabel struct {
@@ -41,7 +43,6 @@ type metricLabel struct {
 func TestNewReceiver(t *testing.T) {
 	type args struct {
 		addr         string
-		timeout      time.Duration
 		attrsPrefix  string
 		nextConsumer consumer.MetricsConsumer
 	}
@@ -54,7 +55,6 @@ func TestNewReceiver(t *testing.T) {
 			name: "nil nextConsumer",
 			args: args{
 				addr:        ":0",
-				timeout:     defaultTimeout,
 				attrsPrefix: "default_attr_",
 			},
 			wantErr: errNilNextConsumer,
@@ -63,7 +63,6 @@ func TestNewReceiver(t *testing.T) {
 			name: "happy path",
 			args: args{
 				addr:         ":0",
-				timeout:      defaultTimeout,
 				attrsPrefix:  "default_attr_",
 				nextConsumer: exportertest.NewNopMetricsExporter(),
 			},
@@ -72,7 +71,7 @@ func TestNewReceiver(t *testing.T) {
 	logger := zap.NewNop()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := New(logger, tt.args.addr, time.Second*10, "", tt.args.nextConsumer)
+			_, err := New("collectd", logger, tt.args.addr, "", httpserver.Settings{}, RecordSettings{}, tt.args.nextConsumer)
 			if err != tt.wantErr {
 				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -81,6 +80,68 @@ func TestNewReceiver(t *testing.T) {
 	}
 }
 
+func TestAcceptsPlugin(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	tests := []struct {
+		name     string
+		settings RecordSettings
+		plugin   *string
+		want     bool
+	}{
+		{
+			name:   "no_filters",
+			plugin: strPtr("cpu"),
+			want:   true,
+		},
+		{
+			name:     "included",
+			settings: RecordSettings{IncludePlugins: []string{"cpu", "memory"}},
+			plugin:   strPtr("cpu"),
+			want:     true,
+		},
+		{
+			name:     "not_included",
+			settings: RecordSettings{IncludePlugins: []string{"memory"}},
+			plugin:   strPtr("cpu"),
+			want:     false,
+		},
+		{
+			name:     "nil_plugin_not_included",
+			settings: RecordSettings{IncludePlugins: []string{"memory"}},
+			want:     false,
+		},
+		{
+			name:     "excluded",
+			settings: RecordSettings{ExcludePlugins: []string{"cpu"}},
+			plugin:   strPtr("cpu"),
+			want:     false,
+		},
+		{
+			name: "included_and_excluded",
+			settings: RecordSettings{
+				IncludePlugins: []string{"cpu", "memory"},
+				ExcludePlugins: []string{"cpu"},
+			},
+			plugin: strPtr("cpu"),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(
+				"collectd",
+				zap.NewNop(),
+				":0",
+				"",
+				httpserver.Settings{},
+				tt.settings,
+				exportertest.NewNopMetricsExporter())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.(*collectdReceiver).acceptsPlugin(tt.plugin))
+		})
+	}
+}
+
 func TestCollectDServer(t *testing.T) {
 	const endpoint = "localhost:8081"
 	defaultAttrsPrefix := "dap_"
@@ -155,7 +216,7 @@ func TestCollectDServer(t *testing.T) {
 	sink := newMockMetricsSink(1)
 
 	logger := zap.NewNop()
-	cdr, err := New(logger, endpoint, defaultTimeout, defaultAttrsPrefix, sink)
+	cdr, err := New("collectd", logger, endpoint, defaultAttrsPrefix, httpserver.Settings{}, RecordSettings{}, sink)
 	if err != nil {
 		t.Fatalf("Failed to create receiver: %v", err)
 	}
@@ -12,8 +12,10 @@ require (
 	github.com/jaegertracing/jaeger v1.15.1
 	github.com/jstemmer/go-junit-report v0.9.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector v0.2.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/client v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver v0.0.0
 	github.com/prometheus/client_model v0.0.0-20191202183732-d1d2010b5bee // indirect
-	github.com/signalfx/sapm-proto v0.3.0
+	github.com/signalfx/sapm-proto v0.4.0
 	github.com/stretchr/testify v1.4.0
 	go.opencensus.io v0.22.2
 	go.uber.org/zap v1.13.0
@@ -30,3 +32,9 @@ require (
 	google.golang.org/grpc v1.25.1 // indirect
 	gopkg.in/yaml.v2 v2.2.7 // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/client => ../../internal/client
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver => ../../internal/httpserver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../../extension/auth
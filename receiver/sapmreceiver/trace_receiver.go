@@ -36,6 +36,8 @@ import (
 	"github.com/signalfx/sapm-proto/sapmprotocol"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/client"
 )
 
 const (
@@ -87,6 +89,8 @@ func (sr *sapmReceiver) handleRequest(ctx context.Context, req *http.Request) er
 		// pass the trace data to the next consumer
 		err = sr.nextConsumer.ConsumeTraceData(ctx, td)
 		if err != nil {
+			// In case of error assume that all spans in the batch were dropped.
+			observability.RecordMetricsForTraceReceiver(ctx, len(batch.Spans), len(batch.Spans))
 			return fmt.Errorf("error passing trace data to next consumer: %v", err.Error())
 		}
 
@@ -99,8 +103,9 @@ func (sr *sapmReceiver) handleRequest(ctx context.Context, req *http.Request) er
 
 // HTTPHandlerFunction returns an http.HandlerFunc that handles SAPM requests
 func (sr *sapmReceiver) HTTPHandlerFunc(rw http.ResponseWriter, req *http.Request) {
-	// create context with the receiver name from the request context
-	ctx := observability.ContextWithReceiverName(req.Context(), "sapm")
+	// create context with the receiver name and the request headers from the request context
+	ctx := observability.ContextWithReceiverName(req.Context(), sr.config.Name())
+	ctx = client.NewContext(ctx, client.Metadata(req.Header))
 
 	// trace this request
 	_, span := trace.StartSpan(ctx, traceSource)
@@ -174,21 +179,30 @@ func (sr *sapmReceiver) Start(host component.Host) error {
 
 	var err = oterr.ErrAlreadyStarted
 	sr.startOnce.Do(func() {
+		// use gorilla mux to create a router/handler
+		nr := mux.NewRouter()
+		nr.HandleFunc(sapmprotocol.TraceEndpointV2, sr.HTTPHandlerFunc)
+		var handler http.Handler
+		handler, err = sr.config.Settings.Handler(nr)
+		if err != nil {
+			return
+		}
+
 		var ln net.Listener
 
 		// set up the listener
-		ln, err = net.Listen("tcp", sr.config.Endpoint)
+		ln, err = sr.config.Listen(sr.config.Endpoint)
 		if err != nil {
 			err = fmt.Errorf("failed to bind to address %s: %v", sr.config.Endpoint, err)
 			return
 		}
 
-		// use gorilla mux to create a router/handler
-		nr := mux.NewRouter()
-		nr.HandleFunc(sapmprotocol.TraceEndpointV2, sr.HTTPHandlerFunc)
-
 		// create a server with the handler
-		sr.server = &http.Server{Handler: nr}
+		sr.server = &http.Server{
+			Handler:      handler,
+			ReadTimeout:  sr.config.ReadTimeout,
+			WriteTimeout: sr.config.WriteTimeout,
+		}
 
 		// run the server on a routine
 		go func() {
@@ -14,9 +14,17 @@
 
 package signalfxreceiver
 
-import "github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+import (
+	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
+)
 
 // Config defines configuration for the SignalFx receiver.
 type Config struct {
 	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Settings holds the TLS, CORS, authentication, request size and timeout
+	// settings of the server.
+	httpserver.Settings `mapstructure:",squash"`
 }
@@ -17,11 +17,14 @@ package signalfxreceiver
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector/config"
 	"github.com/open-telemetry/opentelemetry-collector/config/configmodels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -50,5 +53,16 @@ func TestLoadConfig(t *testing.T) {
 				NameVal:  "signalfx/allsettings",
 				Endpoint: "localhost:8080",
 			},
+			Settings: httpserver.Settings{
+				TLSSetting: &httpserver.TLSSetting{
+					CertFile: "/test.crt",
+					KeyFile:  "/test.key",
+				},
+				CORSAllowedOrigins: []string{"https://*.example.com"},
+				MaxRequestBodySize: 1048576,
+				Authenticator:      "oidc",
+				ReadTimeout:        10 * time.Second,
+				WriteTimeout:       5 * time.Second,
+			},
 		})
 }
@@ -22,6 +22,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector/consumer"
 	"github.com/open-telemetry/opentelemetry-collector/receiver"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver"
 )
 
 // This file implements factory for SignalFx receiver.
@@ -54,6 +56,9 @@ func (f *Factory) CreateDefaultConfig() configmodels.Receiver {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		Settings: httpserver.Settings{
+			WriteTimeout: defaultServerTimeout,
+		},
 	}
 }
 
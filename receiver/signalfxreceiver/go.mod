@@ -8,6 +8,9 @@ require (
 	github.com/gorilla/mux v1.7.3
 	github.com/open-telemetry/opentelemetry-collector v0.2.5
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter v0.0.0-20200110233337-37711984b8d4
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/client v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver v0.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator v0.0.0
 	github.com/signalfx/com_signalfx_metrics_protobuf v0.0.0-20190530013331-054be550cb49
 	github.com/stretchr/testify v1.4.0
 	go.opencensus.io v0.22.1
@@ -15,3 +18,15 @@ require (
 )
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter => ../../exporter/signalfxexporter
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr => ../../internal/batchperresourceattr
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry => ../../internal/resourcetotelemetry
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/client => ../../internal/client
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver => ../../internal/httpserver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../../extension/auth
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator => ../../internal/signalfxtranslator
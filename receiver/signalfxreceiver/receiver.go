@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -35,6 +36,9 @@ import (
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/client"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator"
 )
 
 const (
@@ -108,18 +112,12 @@ func New(
 		config:       &config,
 		nextConsumer: nextConsumer,
 		server: &http.Server{
-			Addr: config.Endpoint,
-			// TODO: Evaluate what properties should be configurable, for now
-			//		set some hard-coded values.
 			ReadHeaderTimeout: defaultServerTimeout,
-			WriteTimeout:      defaultServerTimeout,
+			ReadTimeout:       config.ReadTimeout,
+			WriteTimeout:      config.WriteTimeout,
 		},
 	}
 
-	mux := mux.NewRouter()
-	mux.HandleFunc("/v2/datapoint", r.handleReq)
-	r.server.Handler = mux
-
 	return r, nil
 }
 
@@ -132,10 +130,21 @@ func (r *sfxReceiver) Start(host component.Host) error {
 
 	err := oterr.ErrAlreadyStarted
 	r.startOnce.Do(func() {
-		err = nil
+		mux := mux.NewRouter()
+		mux.HandleFunc("/v2/datapoint", r.handleReq)
+		r.server.Handler, err = r.config.Handler(mux)
+		if err != nil {
+			return
+		}
+
+		var ln net.Listener
+		ln, err = r.config.Listen(r.config.Endpoint)
+		if err != nil {
+			return
+		}
 
 		go func() {
-			if err := r.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			if err := r.server.Serve(ln); err != nil && err != http.ErrServerClosed {
 				host.ReportFatalError(err)
 			}
 		}()
@@ -158,8 +167,9 @@ func (r *sfxReceiver) Shutdown() error {
 }
 
 func (r *sfxReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
-	// Tracing the request to make it visible via z-pages.
-	reqCtx := req.Context()
+	// Tracing the request to make it visible via z-pages. The headers are
+	// kept in the context for the next components.
+	reqCtx := client.NewContext(req.Context(), client.Metadata(req.Header))
 	spanCtx, span := trace.StartSpan(reqCtx, r.config.Name())
 	defer span.End()
 
@@ -208,9 +218,9 @@ func (r *sfxReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	md, numDroppedTimeseries := SignalFxV2ToMetricsData(r.logger, msg.Datapoints)
+	md, numDroppedTimeseries := signalfxtranslator.SignalFxV2ToMetricsData(r.logger, msg.Datapoints)
 
-	err = r.nextConsumer.ConsumeMetricsData(spanCtx, *md)
+	err = r.nextConsumer.ConsumeMetricsData(recvCtx, *md)
 	if err != nil {
 		observability.RecordMetricsForMetricsReceiver(
 			recvCtx,
@@ -44,6 +44,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator/sfxtest"
 )
 
 func Test_signalfxeceiver_New(t *testing.T) {
@@ -164,16 +165,16 @@ func Test_sfxReceiver_handleReq(t *testing.T) {
 		return &sfxpb.DataPointUploadMessage{
 			Datapoints: []*sfxpb.DataPoint{
 				{
-					Metric: strPtr("single"),
+					Metric: sfxtest.StrPtr("single"),
 					Timestamp: func() *int64 {
 						l := time.Now().Unix() * 1e3
 						return &l
 					}(),
 					Value: &sfxpb.Datum{
-						IntValue: int64Ptr(13),
+						IntValue: sfxtest.Int64Ptr(13),
 					},
-					MetricType: sfxTypePtr(sfxpb.MetricType_GAUGE),
-					Dimensions: buildNDimensions(3),
+					MetricType: sfxtest.SFxTypePtr(sfxpb.MetricType_GAUGE),
+					Dimensions: sfxtest.BuildNDimensions(3),
 				},
 			},
 		}
@@ -70,7 +70,7 @@ func (f *Factory) CreateTraceReceiver(
 ) (receiver.TraceReceiver, error) {
 
 	rCfg := cfg.(*Config)
-	return New(rCfg.Endpoint, rCfg.Category, nextConsumer)
+	return New(rCfg.Name(), rCfg.Endpoint, rCfg.Category, nextConsumer)
 }
 
 // CreateMetricsReceiver creates a metrics receiver based on provided config.
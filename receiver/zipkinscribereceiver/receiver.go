@@ -52,6 +52,7 @@ type scribeReceiver struct {
 
 // New creates the Zipkin Scribe receiver with the given parameters.
 func New(
+	name string,
 	addr string,
 	category string,
 	nextConsumer consumer.TraceConsumer) (receiver.TraceReceiver, error) {
@@ -66,7 +67,7 @@ func New(
 			msgDecoder:          base64.StdEncoding.WithPadding('='),
 			tBinProtocolFactory: thrift.NewTBinaryProtocolFactory(true, false),
 			nextConsumer:        nextConsumer,
-			defaultCtx:          observability.ContextWithReceiverName(context.Background(), "zipkin-scribe"),
+			defaultCtx:          observability.ContextWithReceiverName(context.Background(), name),
 		},
 	}
 	return r, nil
@@ -177,7 +178,9 @@ func (sc *scribeCollector) Log(messages []*scribe.LogEntry) (r scribe.ResultCode
 	tdsSize := 0
 	for _, td := range tds {
 		td.SourceFormat = "zipkin-scribe"
-		sc.nextConsumer.ConsumeTraceData(sc.defaultCtx, td)
+		if err := sc.nextConsumer.ConsumeTraceData(sc.defaultCtx, td); err != nil {
+			continue
+		}
 		tdsSize += len(td.Spans)
 	}
 
@@ -63,7 +63,7 @@ func TestNewReceiver(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := New(tt.args.addr, tt.args.category, tt.args.nextConsumer)
+			_, err := New("zipkin-scribe", tt.args.addr, tt.args.category, tt.args.nextConsumer)
 			if err != tt.wantErr {
 				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -74,7 +74,7 @@ func TestNewReceiver(t *testing.T) {
 
 func TestBadEncodedMessage(t *testing.T) {
 	sink := &mockTraceSink{}
-	traceReceiver, err := New("localhost:0", "zipkin", sink)
+	traceReceiver, err := New("zipkin-scribe", "localhost:0", "zipkin", sink)
 	if err != nil {
 		t.Fatalf("Failed to create receiver: %v", err)
 	}
@@ -98,7 +98,7 @@ func TestBadEncodedMessage(t *testing.T) {
 
 func TestNonEqualCategoryIsIgnored(t *testing.T) {
 	sink := &mockTraceSink{}
-	traceReceiver, err := New("localhost:0", "not-zipkin", sink)
+	traceReceiver, err := New("zipkin-scribe", "localhost:0", "not-zipkin", sink)
 	if err != nil {
 		t.Fatalf("Failed to create receiver: %v", err)
 	}
@@ -129,7 +129,7 @@ func TestScribeReceiverPortAlreadyInUse(t *testing.T) {
 		t.Fatalf("failed to open a port: %v", err)
 	}
 	defer l.Close()
-	traceReceiver, err := New(l.Addr().String(), "zipkin", exportertest.NewNopTraceExporter())
+	traceReceiver, err := New("zipkin-scribe", l.Addr().String(), "zipkin", exportertest.NewNopTraceExporter())
 	if err != nil {
 		t.Fatalf("Failed to create receiver: %v", err)
 	}
@@ -152,7 +152,7 @@ func TestScribeReceiverServer(t *testing.T) {
 	}
 	sink := newMockTraceSink(len(messages))
 
-	traceReceiver, err := New(endpoint, "zipkin", sink)
+	traceReceiver, err := New("zipkin-scribe", endpoint, "zipkin", sink)
 	if err != nil {
 		t.Fatalf("Failed to create receiver: %v", err)
 	}
@@ -0,0 +1,211 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correctness
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// CompareOptions relaxes the comparison of metrics for the information that
+// a translation is known to drop or change.
+type CompareOptions struct {
+	// IgnoreDescription skips the comparison of the metric descriptions.
+	IgnoreDescription bool
+
+	// IgnoreUnit skips the comparison of the metric units.
+	IgnoreUnit bool
+
+	// IgnoreStartTimestamp skips the comparison of the timeseries start
+	// timestamps.
+	IgnoreStartTimestamp bool
+
+	// TimestampPrecision truncates the timestamps to the given precision
+	// before comparing them, eg.: time.Millisecond. Zero keeps the full
+	// precision.
+	TimestampPrecision time.Duration
+}
+
+// Difference describes a mismatch between the expected and the actual
+// metrics.
+type Difference struct {
+	// Path identifies the element that doesn't match, it is composed by the
+	// metric name, the timeseries labels and the compared field.
+	Path string
+
+	// Expected is the representation of the expected value.
+	Expected string
+
+	// Actual is the representation of the actual value.
+	Actual string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", d.Path, d.Expected, d.Actual)
+}
+
+// series holds all the points of a timeseries identified by the metric name
+// and labels, the same timeseries can be split across multiple metrics.
+type series struct {
+	descriptor     *metricspb.MetricDescriptor
+	startTimestamp *timestamp.Timestamp
+	points         []*metricspb.Point
+}
+
+// DiffMetrics compares the expected and actual metrics structurally and
+// returns the differences found, sorted by path. The metrics are matched by
+// name and labels, the order of the metrics, timeseries and labels doesn't
+// matter but the points of each timeseries are compared in timestamp order.
+func DiffMetrics(expected, actual []*metricspb.Metric, opts CompareOptions) []Difference {
+	expSeries := groupSeries(expected)
+	actSeries := groupSeries(actual)
+
+	keys := make([]string, 0, len(expSeries)+len(actSeries))
+	for key := range expSeries {
+		keys = append(keys, key)
+	}
+	for key := range actSeries {
+		if _, ok := expSeries[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diffs []Difference
+	for _, key := range keys {
+		exp, expOk := expSeries[key]
+		act, actOk := actSeries[key]
+		switch {
+		case !actOk:
+			diffs = append(diffs, Difference{Path: key, Expected: "timeseries", Actual: "none"})
+		case !expOk:
+			diffs = append(diffs, Difference{Path: key, Expected: "none", Actual: "timeseries"})
+		default:
+			diffs = append(diffs, diffSeries(key, exp, act, opts)...)
+		}
+	}
+
+	return diffs
+}
+
+func diffSeries(key string, exp, act *series, opts CompareOptions) []Difference {
+	var diffs []Difference
+	add := func(field, expected, actual string) {
+		if expected != actual {
+			diffs = append(diffs, Difference{Path: key + "." + field, Expected: expected, Actual: actual})
+		}
+	}
+
+	add("type", exp.descriptor.GetType().String(), act.descriptor.GetType().String())
+	if !opts.IgnoreDescription {
+		add("description", exp.descriptor.GetDescription(), act.descriptor.GetDescription())
+	}
+	if !opts.IgnoreUnit {
+		add("unit", exp.descriptor.GetUnit(), act.descriptor.GetUnit())
+	}
+	if !opts.IgnoreStartTimestamp {
+		add(
+			"start_timestamp",
+			formatTimestamp(exp.startTimestamp, opts.TimestampPrecision),
+			formatTimestamp(act.startTimestamp, opts.TimestampPrecision))
+	}
+
+	add("points", fmt.Sprint(len(exp.points)), fmt.Sprint(len(act.points)))
+	if len(exp.points) != len(act.points) {
+		return diffs
+	}
+
+	sortPoints(exp.points)
+	sortPoints(act.points)
+	for i := range exp.points {
+		field := fmt.Sprintf("points[%d]", i)
+		add(
+			field+".timestamp",
+			formatTimestamp(exp.points[i].Timestamp, opts.TimestampPrecision),
+			formatTimestamp(act.points[i].Timestamp, opts.TimestampPrecision))
+		add(field+".value", formatValue(exp.points[i]), formatValue(act.points[i]))
+	}
+
+	return diffs
+}
+
+func groupSeries(metrics []*metricspb.Metric) map[string]*series {
+	grouped := make(map[string]*series)
+	for _, metric := range metrics {
+		descriptor := metric.GetMetricDescriptor()
+		for _, ts := range metric.GetTimeseries() {
+			key := seriesKey(descriptor, ts)
+			s, ok := grouped[key]
+			if !ok {
+				s = &series{
+					descriptor:     descriptor,
+					startTimestamp: ts.StartTimestamp,
+				}
+				grouped[key] = s
+			}
+			s.points = append(s.points, ts.Points...)
+		}
+	}
+	return grouped
+}
+
+func seriesKey(descriptor *metricspb.MetricDescriptor, ts *metricspb.TimeSeries) string {
+	labels := make([]string, 0, len(ts.LabelValues))
+	for i, lv := range ts.LabelValues {
+		key := "<missing>"
+		if i < len(descriptor.GetLabelKeys()) {
+			key = descriptor.LabelKeys[i].Key
+		}
+		value := "<null>"
+		if lv.GetHasValue() {
+			value = lv.Value
+		}
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return descriptor.GetName() + "{" + strings.Join(labels, ",") + "}"
+}
+
+func sortPoints(points []*metricspb.Point) {
+	sort.SliceStable(points, func(i, j int) bool {
+		ti, tj := points[i].GetTimestamp(), points[j].GetTimestamp()
+		if ti.GetSeconds() != tj.GetSeconds() {
+			return ti.GetSeconds() < tj.GetSeconds()
+		}
+		return ti.GetNanos() < tj.GetNanos()
+	})
+}
+
+func formatTimestamp(ts *timestamp.Timestamp, precision time.Duration) string {
+	if ts == nil {
+		return "<nil>"
+	}
+	t := time.Unix(ts.Seconds, int64(ts.Nanos)).UTC()
+	if precision > 0 {
+		t = t.Truncate(precision)
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatValue(point *metricspb.Point) string {
+	// Only the value is relevant, the timestamp is compared separately.
+	return proto.CompactTextString(&metricspb.Point{Value: point.Value})
+}
@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correctness
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOptions = GeneratorOptions{
+	Seed: 42,
+	Types: []metricspb.MetricDescriptor_Type{
+		metricspb.MetricDescriptor_GAUGE_INT64,
+		metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		metricspb.MetricDescriptor_CUMULATIVE_DOUBLE,
+	},
+	TimeseriesPerMetric: 3,
+	LabelsPerTimeseries: 2,
+	PointsPerTimeseries: 4,
+	StartTime:           time.Unix(1580000000, 0),
+	Interval:            time.Second,
+}
+
+func TestGenerateMetrics(t *testing.T) {
+	md, err := GenerateMetrics(testOptions)
+	require.NoError(t, err)
+	require.Len(t, md.Metrics, 4)
+	assert.Equal(t, 4*3*4, countPoints(md.Metrics))
+
+	again, err := GenerateMetrics(testOptions)
+	require.NoError(t, err)
+	assert.Empty(t, DiffMetrics(md.Metrics, again.Metrics, CompareOptions{}))
+
+	for _, metric := range md.Metrics {
+		cumulative := metric.MetricDescriptor.Type == metricspb.MetricDescriptor_CUMULATIVE_INT64 ||
+			metric.MetricDescriptor.Type == metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
+		for _, ts := range metric.Timeseries {
+			assert.Equal(t, cumulative, ts.StartTimestamp != nil)
+		}
+	}
+
+	_, err = GenerateMetrics(GeneratorOptions{
+		Types: []metricspb.MetricDescriptor_Type{metricspb.MetricDescriptor_SUMMARY},
+	})
+	assert.Error(t, err)
+}
+
+func TestDiffMetrics(t *testing.T) {
+	md, err := GenerateMetrics(testOptions)
+	require.NoError(t, err)
+	expected := md.Metrics
+
+	actual := cloneMetrics(expected)
+	// Order of metrics and points must not matter.
+	actual[0], actual[1] = actual[1], actual[0]
+	points := actual[2].Timeseries[0].Points
+	points[0], points[1] = points[1], points[0]
+	assert.Empty(t, DiffMetrics(expected, actual, CompareOptions{}))
+
+	actual = cloneMetrics(expected)
+	actual[0].MetricDescriptor.Type = metricspb.MetricDescriptor_CUMULATIVE_INT64
+	actual[1].Timeseries[0].Points[2].Value = &metricspb.Point_DoubleValue{DoubleValue: -1}
+	actual[2].Timeseries[1].Points[0].Timestamp.Nanos = 1000
+	actual[3].Timeseries = actual[3].Timeseries[1:]
+
+	diffs := DiffMetrics(expected, actual, CompareOptions{})
+	paths := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		paths = append(paths, diff.Path)
+	}
+	assert.Equal(t, []string{
+		"metric_cumulative_double{label_0=value_0_0,label_1=value_0_1}",
+		"metric_cumulative_int64{label_0=value_1_0,label_1=value_1_1}.points[0].timestamp",
+		"metric_gauge_double{label_0=value_0_0,label_1=value_0_1}.points[2].value",
+		"metric_gauge_int64{label_0=value_0_0,label_1=value_0_1}.type",
+		"metric_gauge_int64{label_0=value_1_0,label_1=value_1_1}.type",
+		"metric_gauge_int64{label_0=value_2_0,label_1=value_2_1}.type",
+	}, paths)
+
+	// The timestamp difference is below the compared precision.
+	diffs = DiffMetrics(expected, actual, CompareOptions{TimestampPrecision: time.Millisecond})
+	assert.Len(t, diffs, 5)
+}
+
+func TestDiffMetrics_Relaxed(t *testing.T) {
+	md, err := GenerateMetrics(testOptions)
+	require.NoError(t, err)
+	expected := md.Metrics
+
+	actual := cloneMetrics(expected)
+	for _, metric := range actual {
+		metric.MetricDescriptor.Description = ""
+		metric.MetricDescriptor.Unit = ""
+		for _, ts := range metric.Timeseries {
+			ts.StartTimestamp = nil
+		}
+	}
+	// Description and unit of every timeseries plus the start timestamp of
+	// the cumulative ones.
+	assert.Len(t, DiffMetrics(expected, actual, CompareOptions{}), 12*2+6)
+	assert.Empty(t, DiffMetrics(expected, actual, CompareOptions{
+		IgnoreDescription:    true,
+		IgnoreUnit:           true,
+		IgnoreStartTimestamp: true,
+	}))
+}
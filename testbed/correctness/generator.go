@@ -0,0 +1,158 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correctness
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+)
+
+// GeneratorOptions controls the shape of the metrics created by
+// GenerateMetrics.
+type GeneratorOptions struct {
+	// Seed of the pseudo-random values, the same options always generate the
+	// same metrics.
+	Seed int64
+
+	// Types of the metrics to generate, one metric is created for each type.
+	// Only the GAUGE and CUMULATIVE types with int64 or double values are
+	// supported.
+	Types []metricspb.MetricDescriptor_Type
+
+	// TimeseriesPerMetric is the number of timeseries of each metric.
+	TimeseriesPerMetric int
+
+	// LabelsPerTimeseries is the number of labels of each timeseries.
+	LabelsPerTimeseries int
+
+	// PointsPerTimeseries is the number of points of each timeseries.
+	PointsPerTimeseries int
+
+	// StartTime is the timestamp of the first point of each timeseries and
+	// the start timestamp of the cumulative ones.
+	StartTime time.Time
+
+	// Interval is the time between consecutive points of a timeseries.
+	Interval time.Duration
+}
+
+// GenerateMetrics creates a deterministic set of metrics according to the
+// given options. The values of cumulative timeseries are monotonically
+// increasing and double values are never integral so they keep their type
+// across protocols that don't carry it.
+func GenerateMetrics(opts GeneratorOptions) (consumerdata.MetricsData, error) {
+	rnd := rand.New(rand.NewSource(opts.Seed))
+
+	labelKeys := make([]*metricspb.LabelKey, 0, opts.LabelsPerTimeseries)
+	for i := 0; i < opts.LabelsPerTimeseries; i++ {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: fmt.Sprintf("label_%d", i)})
+	}
+
+	metrics := make([]*metricspb.Metric, 0, len(opts.Types))
+	for _, metricType := range opts.Types {
+		switch metricType {
+		case metricspb.MetricDescriptor_GAUGE_INT64,
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			metricspb.MetricDescriptor_CUMULATIVE_INT64,
+			metricspb.MetricDescriptor_CUMULATIVE_DOUBLE:
+		default:
+			return consumerdata.MetricsData{}, fmt.Errorf("unsupported metric type %v", metricType)
+		}
+
+		metric := &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        "metric_" + strings.ToLower(metricType.String()),
+				Description: fmt.Sprintf("Generated %v metric", metricType),
+				Unit:        "1",
+				Type:        metricType,
+				LabelKeys:   labelKeys,
+			},
+			Timeseries: make([]*metricspb.TimeSeries, 0, opts.TimeseriesPerMetric),
+		}
+		for i := 0; i < opts.TimeseriesPerMetric; i++ {
+			metric.Timeseries = append(metric.Timeseries, generateTimeseries(rnd, opts, metricType, i))
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return consumerdata.MetricsData{Metrics: metrics}, nil
+}
+
+func generateTimeseries(
+	rnd *rand.Rand,
+	opts GeneratorOptions,
+	metricType metricspb.MetricDescriptor_Type,
+	index int,
+) *metricspb.TimeSeries {
+	ts := &metricspb.TimeSeries{
+		LabelValues: make([]*metricspb.LabelValue, 0, opts.LabelsPerTimeseries),
+		Points:      make([]*metricspb.Point, 0, opts.PointsPerTimeseries),
+	}
+	for i := 0; i < opts.LabelsPerTimeseries; i++ {
+		ts.LabelValues = append(ts.LabelValues, &metricspb.LabelValue{
+			Value:    fmt.Sprintf("value_%d_%d", index, i),
+			HasValue: true,
+		})
+	}
+
+	cumulative := metricType == metricspb.MetricDescriptor_CUMULATIVE_INT64 ||
+		metricType == metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
+	if cumulative {
+		ts.StartTimestamp = toTimestamp(opts.StartTime)
+	}
+
+	// Keep a fractional part on doubles so they are not read back as integers.
+	var intVal int64
+	doubleVal := 0.5
+	for i := 0; i < opts.PointsPerTimeseries; i++ {
+		point := &metricspb.Point{
+			Timestamp: toTimestamp(opts.StartTime.Add(time.Duration(i) * opts.Interval)),
+		}
+
+		switch metricType {
+		case metricspb.MetricDescriptor_GAUGE_INT64, metricspb.MetricDescriptor_CUMULATIVE_INT64:
+			if cumulative {
+				intVal += rnd.Int63n(100)
+			} else {
+				intVal = rnd.Int63n(1000)
+			}
+			point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
+		default:
+			if cumulative {
+				doubleVal += float64(rnd.Int63n(100))
+			} else {
+				doubleVal = float64(rnd.Int63n(1000)) + 0.5
+			}
+			point.Value = &metricspb.Point_DoubleValue{DoubleValue: doubleVal}
+		}
+
+		ts.Points = append(ts.Points, point)
+	}
+
+	return ts
+}
+
+func toTimestamp(t time.Time) *timestamp.Timestamp {
+	return &timestamp.Timestamp{
+		Seconds: t.Unix(),
+		Nanos:   int32(t.Nanosecond()),
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correctness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/open-telemetry/opentelemetry-collector/component"
+	"github.com/open-telemetry/opentelemetry-collector/consumer"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/exporter"
+	"github.com/open-telemetry/opentelemetry-collector/exporter/exportertest"
+	"github.com/open-telemetry/opentelemetry-collector/processor"
+	"github.com/open-telemetry/opentelemetry-collector/receiver"
+	"github.com/open-telemetry/opentelemetry-collector/testutils"
+)
+
+const (
+	defaultWaitTimeout = 10 * time.Second
+	waitPollInterval   = 10 * time.Millisecond
+)
+
+// MetricsPipeline describes a chain of components under test: the metrics
+// are sent by the exporter to the receiver, pass through the processors and
+// are then compared to the expected ones.
+type MetricsPipeline struct {
+	// NewExporter creates the exporter that sends the metrics to the given
+	// endpoint.
+	NewExporter func(endpoint string) (exporter.MetricsExporter, error)
+
+	// NewReceiver creates the receiver listening on the given endpoint and
+	// passing the metrics to next.
+	NewReceiver func(endpoint string, next consumer.MetricsConsumer) (receiver.MetricsReceiver, error)
+
+	// NewProcessors optionally creates, in order, the processors placed
+	// after the receiver.
+	NewProcessors []func(next consumer.MetricsConsumer) (processor.MetricsProcessor, error)
+
+	// Expected returns the metrics expected at the end of the pipeline for
+	// the given input, it is where the known effects of the translations are
+	// described. If nil the input itself is expected.
+	Expected func(input []*metricspb.Metric) []*metricspb.Metric
+
+	// CompareOptions relaxes the comparison of the expected and actual
+	// metrics.
+	CompareOptions CompareOptions
+
+	// WaitTimeout is the maximum time to wait for all the points to reach
+	// the end of the pipeline, defaults to 10s.
+	WaitTimeout time.Duration
+}
+
+// RunMetricsPipeline sends the input through the pipeline and reports, as
+// test errors, every difference between the metrics at the end of the
+// pipeline and the expected ones.
+func RunMetricsPipeline(t *testing.T, pipeline MetricsPipeline, input consumerdata.MetricsData) {
+	sink := new(exportertest.SinkMetricsExporter)
+	exp, stop := StartMetricsPipeline(t, pipeline, sink)
+	defer stop()
+
+	// The input is cloned since translations are free to modify it.
+	expected := cloneMetrics(input.Metrics)
+	if pipeline.Expected != nil {
+		expected = pipeline.Expected(cloneMetrics(input.Metrics))
+	}
+
+	if err := exp.ConsumeMetricsData(context.Background(), cloneMetricsData(input)); err != nil {
+		t.Fatalf("failed to export metrics: %v", err)
+	}
+
+	timeout := pipeline.WaitTimeout
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	wantPoints := countPoints(expected)
+	var actual []*metricspb.Metric
+	for deadline := time.Now().Add(timeout); ; time.Sleep(waitPollInterval) {
+		actual = actual[:0]
+		for _, md := range sink.AllMetrics() {
+			actual = append(actual, md.Metrics...)
+		}
+		if countPoints(actual) >= wantPoints || time.Now().After(deadline) {
+			break
+		}
+	}
+
+	for _, diff := range DiffMetrics(expected, actual, pipeline.CompareOptions) {
+		t.Error(diff.String())
+	}
+}
+
+// StartMetricsPipeline creates and starts the components of the pipeline,
+// with next placed after the processors, and returns the exporter feeding it.
+// The returned function shuts the components down.
+func StartMetricsPipeline(t *testing.T, pipeline MetricsPipeline, next consumer.MetricsConsumer) (exporter.MetricsExporter, func()) {
+	host := component.NewMockHost()
+	var shutdowns []func() error
+	stop := func() {
+		// Shut down from the exporter to the end of the pipeline.
+		for i := len(shutdowns) - 1; i >= 0; i-- {
+			shutdowns[i]()
+		}
+	}
+
+	for i := len(pipeline.NewProcessors) - 1; i >= 0; i-- {
+		proc, err := pipeline.NewProcessors[i](next)
+		if err != nil {
+			stop()
+			t.Fatalf("failed to create processor %d: %v", i, err)
+		}
+		if err := proc.Start(host); err != nil {
+			stop()
+			t.Fatalf("failed to start processor %d: %v", i, err)
+		}
+		shutdowns = append(shutdowns, proc.Shutdown)
+		next = proc
+	}
+
+	endpoint := testutils.GetAvailableLocalAddress(t)
+	rcv, err := pipeline.NewReceiver(endpoint, next)
+	if err != nil {
+		stop()
+		t.Fatalf("failed to create receiver: %v", err)
+	}
+	if err := rcv.Start(host); err != nil {
+		stop()
+		t.Fatalf("failed to start receiver: %v", err)
+	}
+	shutdowns = append(shutdowns, rcv.Shutdown)
+
+	exp, err := pipeline.NewExporter(endpoint)
+	if err != nil {
+		stop()
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+	if err := exp.Start(host); err != nil {
+		stop()
+		t.Fatalf("failed to start exporter: %v", err)
+	}
+	shutdowns = append(shutdowns, exp.Shutdown)
+
+	return exp, stop
+}
+
+func countPoints(metrics []*metricspb.Metric) int {
+	count := 0
+	for _, metric := range metrics {
+		for _, ts := range metric.GetTimeseries() {
+			count += len(ts.GetPoints())
+		}
+	}
+	return count
+}
+
+func cloneMetricsData(md consumerdata.MetricsData) consumerdata.MetricsData {
+	clone := consumerdata.MetricsData{Metrics: cloneMetrics(md.Metrics)}
+	if md.Node != nil {
+		clone.Node = proto.Clone(md.Node).(*commonpb.Node)
+	}
+	if md.Resource != nil {
+		clone.Resource = proto.Clone(md.Resource).(*resourcepb.Resource)
+	}
+	return clone
+}
+
+func cloneMetrics(metrics []*metricspb.Metric) []*metricspb.Metric {
+	clone := make([]*metricspb.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		clone = append(clone, proto.Clone(metric).(*metricspb.Metric))
+	}
+	return clone
+}
@@ -26,3 +26,15 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbo
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sapmreceiver => ../receiver/sapmreceiver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/signalfxreceiver => ../receiver/signalfxreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/batchperresourceattr => ../internal/batchperresourceattr
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/resourcetotelemetry => ../internal/resourcetotelemetry
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/client => ../internal/client
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/httpserver => ../internal/httpserver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/auth => ../extension/auth
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/signalfxtranslator => ../internal/signalfxtranslator
@@ -0,0 +1,67 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// sample is a measurement of the resources used by the test process.
+type sample struct {
+	// Elapsed is the time since the start of the test.
+	Elapsed time.Duration
+	// RSS is the resident set size in bytes, zero if it is not available
+	// on the platform.
+	RSS uint64
+	// HeapAlloc is the size in bytes of the live objects on the heap.
+	HeapAlloc uint64
+	// Goroutines is the number of goroutines.
+	Goroutines int
+}
+
+// collectSamples takes a sample at each interval until the duration elapses.
+func collectSamples(duration, interval time.Duration) []sample {
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var samples []sample
+	for now := range ticker.C {
+		elapsed := now.Sub(start)
+		if elapsed > duration {
+			break
+		}
+		samples = append(samples, takeSample(elapsed))
+	}
+	return samples
+}
+
+// takeSample collects the garbage and returns the memory to the OS before
+// measuring, otherwise the measurements would mostly reflect the pace of the
+// garbage collector.
+func takeSample(elapsed time.Duration) sample {
+	debug.FreeOSMemory()
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return sample{
+		Elapsed:    elapsed,
+		RSS:        readRSS(),
+		HeapAlloc:  ms.HeapAlloc,
+		Goroutines: runtime.NumGoroutine(),
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/correctness"
+)
+
+const (
+	defaultSampleInterval     = 10 * time.Second
+	defaultBatchInterval      = 100 * time.Millisecond
+	defaultWindows            = 4
+	defaultMaxMemoryGrowth    = 0.1
+	defaultMaxGoroutineGrowth = 10
+)
+
+// Options controls the load and the leak detection of a soak test.
+type Options struct {
+	// Duration is how long the load is sent, it should be long enough for
+	// leaks to stand out from the noise, eg.: hours.
+	Duration time.Duration
+
+	// WarmUp is the initial part of the test whose samples are ignored,
+	// while caches and buffers fill up. Defaults to a tenth of the duration.
+	WarmUp time.Duration
+
+	// SampleInterval is the time between samples, defaults to 10s.
+	SampleInterval time.Duration
+
+	// BatchInterval is the time between the batches sent through the
+	// pipeline, defaults to 100ms.
+	BatchInterval time.Duration
+
+	// Windows is the number of consecutive windows the samples are split
+	// into after the warm up. A leak is reported when the median of a
+	// resource grows from each window to the next one. Defaults to 4.
+	Windows int
+
+	// MaxMemoryGrowth is the growth of the memory, relative to the first
+	// window, tolerated even if it is monotonic. Defaults to 0.1, ie.: 10%.
+	MaxMemoryGrowth float64
+
+	// MaxGoroutineGrowth is the growth of the number of goroutines tolerated
+	// even if it is monotonic. Defaults to 10.
+	MaxGoroutineGrowth int
+}
+
+func (o Options) withDefaults() Options {
+	if o.WarmUp <= 0 {
+		o.WarmUp = o.Duration / 10
+	}
+	if o.SampleInterval <= 0 {
+		o.SampleInterval = defaultSampleInterval
+	}
+	if o.BatchInterval <= 0 {
+		o.BatchInterval = defaultBatchInterval
+	}
+	if o.Windows < 2 {
+		o.Windows = defaultWindows
+	}
+	if o.MaxMemoryGrowth <= 0 {
+		o.MaxMemoryGrowth = defaultMaxMemoryGrowth
+	}
+	if o.MaxGoroutineGrowth <= 0 {
+		o.MaxGoroutineGrowth = defaultMaxGoroutineGrowth
+	}
+	return o
+}
+
+// RunMetricsPipeline sends the batches created by generate, each one given
+// its sequence number, through the pipeline for the duration of the test and
+// reports a test error for each resource that kept growing. The pipeline runs
+// in the test process, so the samples measure the components under test plus
+// the steady overhead of the harness.
+func RunMetricsPipeline(
+	t *testing.T,
+	pipeline correctness.MetricsPipeline,
+	generate func(batch int) consumerdata.MetricsData,
+	opts Options,
+) {
+	opts = opts.withDefaults()
+	if opts.Duration <= opts.WarmUp {
+		t.Fatalf("soak duration %v must be longer than the warm up %v", opts.Duration, opts.WarmUp)
+	}
+
+	// The points are only counted, keeping them would be a leak of its own.
+	sink := new(countingConsumer)
+	exp, stop := correctness.StartMetricsPipeline(t, pipeline, sink)
+	defer stop()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	var sent, failed int64
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(opts.BatchInterval)
+		defer ticker.Stop()
+		for batch := 0; ; batch++ {
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+			if err := exp.ConsumeMetricsData(context.Background(), generate(batch)); err != nil {
+				atomic.AddInt64(&failed, 1)
+				continue
+			}
+			atomic.AddInt64(&sent, 1)
+		}
+	}()
+
+	samples := collectSamples(opts.Duration, opts.SampleInterval)
+	close(done)
+	wg.Wait()
+
+	t.Logf("sent %d batches, %d failed, %d points reached the end of the pipeline",
+		atomic.LoadInt64(&sent), atomic.LoadInt64(&failed), sink.points())
+	if sink.points() == 0 {
+		t.Error("no points reached the end of the pipeline")
+	}
+
+	var analyzed []sample
+	for _, s := range samples {
+		if s.Elapsed >= opts.WarmUp {
+			analyzed = append(analyzed, s)
+		}
+	}
+	for _, g := range detectGrowth(analyzed, opts) {
+		t.Error(g.String())
+	}
+}
+
+// countingConsumer counts the points it receives and discards them.
+type countingConsumer struct {
+	count int64
+}
+
+func (c *countingConsumer) ConsumeMetricsData(_ context.Context, md consumerdata.MetricsData) error {
+	var points int64
+	for _, metric := range md.Metrics {
+		for _, ts := range metric.GetTimeseries() {
+			points += int64(len(ts.GetPoints()))
+		}
+	}
+	atomic.AddInt64(&c.count, points)
+	return nil
+}
+
+func (c *countingConsumer) points() int64 {
+	return atomic.LoadInt64(&c.count)
+}
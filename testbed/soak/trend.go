@@ -0,0 +1,94 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// growth describes a resource that kept growing during a soak test.
+type growth struct {
+	resource string
+	unit     string
+	// medians of the resource in each window.
+	medians []float64
+}
+
+func (g growth) String() string {
+	values := make([]string, 0, len(g.medians))
+	for _, median := range g.medians {
+		values = append(values, fmt.Sprintf("%.0f%s", median, g.unit))
+	}
+	return fmt.Sprintf("%s kept growing, window medians: %s", g.resource, strings.Join(values, " -> "))
+}
+
+// detectGrowth splits the samples into consecutive windows and returns the
+// resources whose median grew from each window to the next one by more than
+// the tolerated growth overall. Comparing medians of windows, instead of
+// individual samples, ignores the spikes caused by bursts of work.
+func detectGrowth(samples []sample, opts Options) []growth {
+	opts = opts.withDefaults()
+	if len(samples) < opts.Windows {
+		return nil
+	}
+
+	var growths []growth
+	check := func(resource, unit string, value func(sample) float64, tolerated func(first float64) float64) {
+		medians := windowMedians(samples, opts.Windows, value)
+		for i := 1; i < len(medians); i++ {
+			if medians[i] <= medians[i-1] {
+				return
+			}
+		}
+		if medians[len(medians)-1]-medians[0] <= tolerated(medians[0]) {
+			return
+		}
+		growths = append(growths, growth{resource: resource, unit: unit, medians: medians})
+	}
+
+	relativeMemoryGrowth := func(first float64) float64 {
+		return first * opts.MaxMemoryGrowth
+	}
+	if samples[0].RSS > 0 {
+		check("RSS", "B", func(s sample) float64 { return float64(s.RSS) }, relativeMemoryGrowth)
+	}
+	check("Heap", "B", func(s sample) float64 { return float64(s.HeapAlloc) }, relativeMemoryGrowth)
+	check("Goroutines", "", func(s sample) float64 { return float64(s.Goroutines) }, func(float64) float64 {
+		return float64(opts.MaxGoroutineGrowth)
+	})
+	return growths
+}
+
+// windowMedians returns the median value of each of the given number of
+// consecutive windows of samples.
+func windowMedians(samples []sample, windows int, value func(sample) float64) []float64 {
+	medians := make([]float64, 0, windows)
+	for i := 0; i < windows; i++ {
+		window := samples[i*len(samples)/windows : (i+1)*len(samples)/windows]
+		values := make([]float64, 0, len(window))
+		for _, s := range window {
+			values = append(values, value(s))
+		}
+		sort.Float64s(values)
+		median := values[len(values)/2]
+		if len(values)%2 == 0 {
+			median = (values[len(values)/2-1] + median) / 2
+		}
+		medians = append(medians, median)
+	}
+	return medians
+}
@@ -0,0 +1,119 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeSamples(n int, rss func(i int) uint64, goroutines func(i int) int) []sample {
+	samples := make([]sample, 0, n)
+	for i := 0; i < n; i++ {
+		samples = append(samples, sample{
+			Elapsed:    time.Duration(i) * time.Second,
+			RSS:        rss(i),
+			HeapAlloc:  10 << 20,
+			Goroutines: goroutines(i),
+		})
+	}
+	return samples
+}
+
+func steadyGoroutines(int) int { return 50 }
+
+func TestDetectGrowth(t *testing.T) {
+	tests := []struct {
+		name      string
+		samples   []sample
+		resources []string
+	}{
+		{
+			name: "steady",
+			samples: makeSamples(100, func(i int) uint64 {
+				return 100<<20 + uint64(i%7)<<20
+			}, steadyGoroutines),
+		},
+		{
+			name: "memory leak",
+			samples: makeSamples(100, func(i int) uint64 {
+				return 100<<20 + uint64(i)<<20
+			}, steadyGoroutines),
+			resources: []string{"RSS"},
+		},
+		{
+			name: "small monotonic growth is tolerated",
+			samples: makeSamples(100, func(i int) uint64 {
+				return 100<<20 + uint64(i)<<16
+			}, steadyGoroutines),
+		},
+		{
+			name: "growth that stops is not a leak",
+			samples: makeSamples(100, func(i int) uint64 {
+				if i > 30 {
+					i = 30
+				}
+				return 100<<20 + uint64(i)<<20
+			}, steadyGoroutines),
+		},
+		{
+			name: "spikes are ignored",
+			samples: makeSamples(100, func(i int) uint64 {
+				if i%25 == 24 {
+					return 400 << 20
+				}
+				return 100 << 20
+			}, steadyGoroutines),
+		},
+		{
+			name: "goroutine leak",
+			samples: makeSamples(100, func(int) uint64 {
+				return 100 << 20
+			}, func(i int) int {
+				return 50 + i
+			}),
+			resources: []string{"Goroutines"},
+		},
+		{
+			name: "RSS not available",
+			samples: makeSamples(100, func(int) uint64 {
+				return 0
+			}, steadyGoroutines),
+		},
+		{
+			name: "too few samples",
+			samples: makeSamples(3, func(i int) uint64 {
+				return 100<<20 + uint64(i)<<30
+			}, steadyGoroutines),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resources []string
+			for _, g := range detectGrowth(tt.samples, Options{}) {
+				resources = append(resources, g.resource)
+			}
+			assert.Equal(t, tt.resources, resources)
+		})
+	}
+}
+
+func TestGrowthString(t *testing.T) {
+	g := growth{resource: "Goroutines", medians: []float64{10, 20, 30}}
+	assert.Equal(t, "Goroutines kept growing, window medians: 10 -> 20 -> 30", g.String())
+}
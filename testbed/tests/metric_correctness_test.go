@@ -0,0 +1,124 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/open-telemetry/opentelemetry-collector/consumer"
+	"github.com/open-telemetry/opentelemetry-collector/exporter"
+	"github.com/open-telemetry/opentelemetry-collector/receiver"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/signalfxreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/correctness"
+)
+
+func TestMetricsCorrectness(t *testing.T) {
+	input, err := correctness.GenerateMetrics(correctness.GeneratorOptions{
+		Seed: 1,
+		Types: []metricspb.MetricDescriptor_Type{
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			metricspb.MetricDescriptor_CUMULATIVE_INT64,
+			metricspb.MetricDescriptor_CUMULATIVE_DOUBLE,
+		},
+		TimeseriesPerMetric: 5,
+		LabelsPerTimeseries: 3,
+		PointsPerTimeseries: 10,
+		StartTime:           time.Unix(1580000000, 123456789),
+		Interval:            10 * time.Second,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		pipeline correctness.MetricsPipeline
+	}{
+		{
+			name: "SignalFx",
+			pipeline: correctness.MetricsPipeline{
+				NewExporter: func(endpoint string) (exporter.MetricsExporter, error) {
+					factory := signalfxexporter.Factory{}
+					cfg := factory.CreateDefaultConfig().(*signalfxexporter.Config)
+					cfg.URL = "http://" + endpoint + "/v2/datapoint"
+					return factory.CreateMetricsExporter(zap.NewNop(), cfg)
+				},
+				NewReceiver: func(endpoint string, next consumer.MetricsConsumer) (receiver.MetricsReceiver, error) {
+					cfg := (&signalfxreceiver.Factory{}).CreateDefaultConfig().(*signalfxreceiver.Config)
+					cfg.Endpoint = endpoint
+					return signalfxreceiver.New(zap.NewNop(), *cfg, next)
+				},
+				// SignalFx has no description, unit or start time and its
+				// timestamps are in milliseconds.
+				CompareOptions: correctness.CompareOptions{
+					IgnoreDescription:    true,
+					IgnoreUnit:           true,
+					IgnoreStartTimestamp: true,
+					TimestampPrecision:   time.Millisecond,
+				},
+			},
+		},
+		{
+			name: "Carbon",
+			pipeline: correctness.MetricsPipeline{
+				NewExporter: func(endpoint string) (exporter.MetricsExporter, error) {
+					factory := carbonexporter.Factory{}
+					cfg := factory.CreateDefaultConfig().(*carbonexporter.Config)
+					cfg.Endpoint = endpoint
+					return factory.CreateMetricsExporter(zap.NewNop(), cfg)
+				},
+				NewReceiver: func(endpoint string, next consumer.MetricsConsumer) (receiver.MetricsReceiver, error) {
+					cfg := (&carbonreceiver.Factory{}).CreateDefaultConfig().(*carbonreceiver.Config)
+					cfg.Endpoint = endpoint
+					return carbonreceiver.New(zap.NewNop(), *cfg, next)
+				},
+				// Carbon only carries gauges, the value type is inferred
+				// from the value itself.
+				Expected: func(input []*metricspb.Metric) []*metricspb.Metric {
+					for _, metric := range input {
+						switch metric.MetricDescriptor.Type {
+						case metricspb.MetricDescriptor_CUMULATIVE_INT64:
+							metric.MetricDescriptor.Type = metricspb.MetricDescriptor_GAUGE_INT64
+						case metricspb.MetricDescriptor_CUMULATIVE_DOUBLE:
+							metric.MetricDescriptor.Type = metricspb.MetricDescriptor_GAUGE_DOUBLE
+						}
+					}
+					return input
+				},
+				// Carbon has no description, unit or start time and its
+				// timestamps are in seconds.
+				CompareOptions: correctness.CompareOptions{
+					IgnoreDescription:    true,
+					IgnoreUnit:           true,
+					IgnoreStartTimestamp: true,
+					TimestampPrecision:   time.Second,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			correctness.RunMetricsPipeline(t, test.pipeline, input)
+		})
+	}
+}
@@ -46,6 +46,15 @@ func TestMetric10kDPS(t *testing.T) {
 				ExpectedMaxRAM: 40,
 			},
 		},
+		{
+			"Carbon UDP",
+			NewCarbonUDPDataSender(testbed.GetAvailablePort(t)),
+			NewCarbonDataReceiver(testbed.GetAvailablePort(t)),
+			testbed.ResourceSpec{
+				ExpectedMaxCPU: 115,
+				ExpectedMaxRAM: 40,
+			},
+		},
 		{
 			"SignalFx",
 			NewSFxMetricDataSender(testbed.GetAvailablePort(t)),
@@ -15,10 +15,14 @@
 package tests
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
 	"github.com/open-telemetry/opentelemetry-collector/exporter"
 	"github.com/open-telemetry/opentelemetry-collector/testbed/testbed"
@@ -206,3 +210,113 @@ func (cs *CarbonDataSender) GetCollectorPort() int {
 func (cs *CarbonDataSender) ProtocolName() string {
 	return "carbon"
 }
+
+// CarbonUDPDataSender implements MetricDataSender for Carbon plaintext
+// protocol over UDP. The Carbon exporter only supports TCP so the plaintext
+// lines are written directly by the sender.
+type CarbonUDPDataSender struct {
+	conn net.Conn
+	port int
+}
+
+// Ensure CarbonUDPDataSender implements MetricDataSender.
+var _ testbed.MetricDataSender = (*CarbonUDPDataSender)(nil)
+
+// maxCarbonUDPPayload keeps each datagram below the typical Ethernet MTU.
+const maxCarbonUDPPayload = 1400
+
+// NewCarbonUDPDataSender creates a new Carbon UDP metric protocol sender that
+// will send to the specified port after Start is called.
+func NewCarbonUDPDataSender(port int) *CarbonUDPDataSender {
+	return &CarbonUDPDataSender{port: port}
+}
+
+// Start the sender.
+func (cs *CarbonUDPDataSender) Start() error {
+	conn, err := net.Dial("udp", fmt.Sprintf("localhost:%d", cs.port))
+	if err != nil {
+		return err
+	}
+	cs.conn = conn
+	return nil
+}
+
+// SendMetrics sends metrics. Can be called after Start. Each data point is
+// sent as a plaintext line and lines are packed in datagrams.
+func (cs *CarbonUDPDataSender) SendMetrics(metrics consumerdata.MetricsData) error {
+	var buf bytes.Buffer
+	for _, metric := range metrics.Metrics {
+		desc := metric.GetMetricDescriptor()
+		for _, ts := range metric.GetTimeseries() {
+			for _, point := range ts.GetPoints() {
+				line := carbonPlaintextLine(desc, ts, point)
+				if buf.Len()+len(line) > maxCarbonUDPPayload && buf.Len() > 0 {
+					if _, err := cs.conn.Write(buf.Bytes()); err != nil {
+						return err
+					}
+					buf.Reset()
+				}
+				buf.WriteString(line)
+			}
+		}
+	}
+	if buf.Len() > 0 {
+		_, err := cs.conn.Write(buf.Bytes())
+		return err
+	}
+	return nil
+}
+
+// carbonPlaintextLine formats a single data point as a Carbon plaintext line
+// using the tag syntax, eg.: "name;key0=value0 42 1585000000\n".
+func carbonPlaintextLine(
+	desc *metricspb.MetricDescriptor,
+	ts *metricspb.TimeSeries,
+	point *metricspb.Point,
+) string {
+	var line bytes.Buffer
+	line.WriteString(desc.GetName())
+	for i, key := range desc.GetLabelKeys() {
+		if i >= len(ts.GetLabelValues()) || !ts.LabelValues[i].GetHasValue() {
+			continue
+		}
+		line.WriteString(";")
+		line.WriteString(key.GetKey())
+		line.WriteString("=")
+		line.WriteString(ts.LabelValues[i].GetValue())
+	}
+	line.WriteString(" ")
+	switch v := point.GetValue().(type) {
+	case *metricspb.Point_Int64Value:
+		line.WriteString(strconv.FormatInt(v.Int64Value, 10))
+	case *metricspb.Point_DoubleValue:
+		line.WriteString(strconv.FormatFloat(v.DoubleValue, 'g', -1, 64))
+	}
+	line.WriteString(" ")
+	line.WriteString(strconv.FormatInt(point.GetTimestamp().GetSeconds(), 10))
+	line.WriteString("\n")
+	return line.String()
+}
+
+// Flush previously sent metrics.
+func (cs *CarbonUDPDataSender) Flush() {
+}
+
+// GenConfigYAMLStr returns receiver config for the agent.
+func (cs *CarbonUDPDataSender) GenConfigYAMLStr() string {
+	// Note that this generates a receiver config for agent.
+	return fmt.Sprintf(`
+  carbon:
+    endpoint: localhost:%d
+    transport: udp`, cs.port)
+}
+
+// GetCollectorPort returns receiver port for the Collector.
+func (cs *CarbonUDPDataSender) GetCollectorPort() int {
+	return cs.port
+}
+
+// ProtocolName returns protocol name as it is specified in Collector config.
+func (cs *CarbonUDPDataSender) ProtocolName() string {
+	return "carbon"
+}
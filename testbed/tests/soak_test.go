@@ -0,0 +1,126 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/open-telemetry/opentelemetry-collector/consumer"
+	"github.com/open-telemetry/opentelemetry-collector/consumer/consumerdata"
+	"github.com/open-telemetry/opentelemetry-collector/exporter"
+	"github.com/open-telemetry/opentelemetry-collector/receiver"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/signalfxreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/correctness"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/soak"
+)
+
+// soakDurationEnv enables the soak tests, eg.: SOAK_DURATION=2h. They are
+// skipped by default since they run for hours.
+const soakDurationEnv = "SOAK_DURATION"
+
+func TestMetricsSoak(t *testing.T) {
+	value, ok := os.LookupEnv(soakDurationEnv)
+	if !ok {
+		t.Skipf("soak tests are only run when %s is set", soakDurationEnv)
+	}
+	duration, err := time.ParseDuration(value)
+	require.NoError(t, err)
+
+	input, err := correctness.GenerateMetrics(correctness.GeneratorOptions{
+		Seed: 1,
+		Types: []metricspb.MetricDescriptor_Type{
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		},
+		TimeseriesPerMetric: 50,
+		LabelsPerTimeseries: 3,
+		PointsPerTimeseries: 1,
+		StartTime:           time.Now(),
+		Interval:            time.Second,
+	})
+	require.NoError(t, err)
+
+	// The same timeseries are sent over and over with new timestamps, like
+	// a scraped target, so stateful components see a stable set of series.
+	generate := func(int) consumerdata.MetricsData {
+		now := time.Now()
+		md := consumerdata.MetricsData{Metrics: make([]*metricspb.Metric, 0, len(input.Metrics))}
+		for _, metric := range input.Metrics {
+			clone := proto.Clone(metric).(*metricspb.Metric)
+			for _, series := range clone.Timeseries {
+				for _, point := range series.Points {
+					point.Timestamp = &timestamp.Timestamp{Seconds: now.Unix(), Nanos: int32(now.Nanosecond())}
+				}
+			}
+			md.Metrics = append(md.Metrics, clone)
+		}
+		return md
+	}
+
+	tests := []struct {
+		name     string
+		pipeline correctness.MetricsPipeline
+	}{
+		{
+			name: "SignalFx",
+			pipeline: correctness.MetricsPipeline{
+				NewExporter: func(endpoint string) (exporter.MetricsExporter, error) {
+					factory := signalfxexporter.Factory{}
+					cfg := factory.CreateDefaultConfig().(*signalfxexporter.Config)
+					cfg.URL = "http://" + endpoint + "/v2/datapoint"
+					return factory.CreateMetricsExporter(zap.NewNop(), cfg)
+				},
+				NewReceiver: func(endpoint string, next consumer.MetricsConsumer) (receiver.MetricsReceiver, error) {
+					cfg := (&signalfxreceiver.Factory{}).CreateDefaultConfig().(*signalfxreceiver.Config)
+					cfg.Endpoint = endpoint
+					return signalfxreceiver.New(zap.NewNop(), *cfg, next)
+				},
+			},
+		},
+		{
+			name: "Carbon",
+			pipeline: correctness.MetricsPipeline{
+				NewExporter: func(endpoint string) (exporter.MetricsExporter, error) {
+					factory := carbonexporter.Factory{}
+					cfg := factory.CreateDefaultConfig().(*carbonexporter.Config)
+					cfg.Endpoint = endpoint
+					return factory.CreateMetricsExporter(zap.NewNop(), cfg)
+				},
+				NewReceiver: func(endpoint string, next consumer.MetricsConsumer) (receiver.MetricsReceiver, error) {
+					cfg := (&carbonreceiver.Factory{}).CreateDefaultConfig().(*carbonreceiver.Config)
+					cfg.Endpoint = endpoint
+					return carbonreceiver.New(zap.NewNop(), *cfg, next)
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			soak.RunMetricsPipeline(t, test.pipeline, generate, soak.Options{Duration: duration})
+		})
+	}
+}